@@ -0,0 +1,23 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache stores encoded values under string keys until they expire. LRU keeps
+// them in process; a shared store such as Redis fits the same methods.
+type Cache interface {
+	// Get returns ok false when key is missing or has expired.
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	// Set stores value under key for ttl, or until evicted when ttl is not positive.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// DeletePrefix removes every key starting with one of prefixes.
+	DeletePrefix(ctx context.Context, prefixes ...string) error
+}
+
+// Stats counts the lookups answered from the cache and those that were not.
+type Stats struct {
+	Hits   uint64
+	Misses uint64
+}
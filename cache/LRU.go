@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// LRU is an in-process Cache holding at most size entries, evicting the least
+// recently used one to make room.
+type LRU struct {
+	size int
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+func NewLRU(size int) *LRU {
+	return &LRU{
+		size:    size,
+		now:     time.Now,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (lru *LRU) Get(_ context.Context, key string) ([]byte, bool, error) {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+
+	element, ok := lru.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*lruEntry)
+	if !entry.expiresAt.IsZero() && !lru.now().Before(entry.expiresAt) {
+		lru.remove(element)
+		return nil, false, nil
+	}
+
+	lru.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+func (lru *LRU) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = lru.now().Add(ttl)
+	}
+
+	if element, ok := lru.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		lru.order.MoveToFront(element)
+		return nil
+	}
+
+	lru.entries[key] = lru.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	for lru.order.Len() > lru.size {
+		lru.remove(lru.order.Back())
+	}
+	return nil
+}
+
+func (lru *LRU) DeletePrefix(_ context.Context, prefixes ...string) error {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+
+	for key, element := range lru.entries {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(key, prefix) {
+				lru.remove(element)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Len returns the number of entries, including expired ones not yet removed.
+func (lru *LRU) Len() int {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+
+	return lru.order.Len()
+}
+
+func (lru *LRU) remove(element *list.Element) {
+	lru.order.Remove(element)
+	delete(lru.entries, element.Value.(*lruEntry).key)
+}
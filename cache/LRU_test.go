@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	lru := NewLRU(2)
+	lru.now = func() time.Time { return now }
+
+	get := func(key string) string {
+		value, ok, err := lru.Get(ctx, key)
+		assert.NoError(t, err)
+		if !ok {
+			return ""
+		}
+		return string(value)
+	}
+
+	assert.NoError(t, lru.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.NoError(t, lru.Set(ctx, "b", []byte("2"), 0))
+	assert.Equal(t, "1", get("a"))
+
+	// "b" is the least recently used entry, so it makes room for "c".
+	assert.NoError(t, lru.Set(ctx, "c", []byte("3"), time.Minute))
+	assert.Equal(t, "", get("b"))
+	assert.Equal(t, "1", get("a"))
+	assert.Equal(t, "3", get("c"))
+	assert.Equal(t, 2, lru.Len())
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, "", get("a"))
+	assert.Equal(t, 1, lru.Len())
+
+	assert.NoError(t, lru.Set(ctx, "c", []byte("4"), time.Minute))
+	assert.Equal(t, "4", get("c"))
+}
+
+func TestLRU_DeletePrefix(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(10)
+
+	for _, key := range []string{"swift:ABCDPLPWXXX", "swift:ABCDPLPW001", "swift:EFGHDEFFXXX", "country:PL"} {
+		assert.NoError(t, lru.Set(ctx, key, []byte(key), 0))
+	}
+
+	assert.NoError(t, lru.DeletePrefix(ctx, "swift:ABCDPLPW", "country:"))
+
+	assert.Equal(t, 1, lru.Len())
+	_, ok, _ := lru.Get(ctx, "swift:EFGHDEFFXXX")
+	assert.True(t, ok)
+}
@@ -2,23 +2,557 @@ package configs
 
 import (
 	"awesomeProject/dbs"
+	"awesomeProject/models"
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"log/slog"
+	"net"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
+const (
+	RepositoryPostgres = "postgres"
+	RepositoryMemory   = "memory"
+)
+
+// ConfigFileEnv names the environment variable used when no config file path is given.
+const ConfigFileEnv = "CONFIG_FILE"
+
+const maskedSecret = "********"
+
+// minJWTSecretLength is the HS256 key size recommended by RFC 7518.
+const minJWTSecretLength = 32
+
+type ServerConfig struct {
+	Address           string        `yaml:"address"`
+	ReadTimeout       time.Duration `yaml:"readTimeout"`
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout"`
+	WriteTimeout      time.Duration `yaml:"writeTimeout"`
+	IdleTimeout       time.Duration `yaml:"idleTimeout"`
+	ShutdownTimeout   time.Duration `yaml:"shutdownTimeout"`
+	// TrustedProxies may set X-Forwarded-For; without any the client IP is the peer address.
+	TrustedProxies []string `yaml:"trustedProxies"`
+}
+
+type ImportConfig struct {
+	Path      string `yaml:"path"`
+	OnStartup bool   `yaml:"onStartup"`
+	Prune     bool   `yaml:"prune"`
+}
+
+type FeaturesConfig struct {
+	Search bool `yaml:"search"`
+	Export bool `yaml:"export"`
+	Bulk   bool `yaml:"bulk"`
+}
+
+// BranchesConfig holds the models.MissingHeadquarter* and models.HeadquarterDelete* policies.
+type BranchesConfig struct {
+	MissingHeadquarter  string `yaml:"missingHeadquarter"`
+	OnHeadquarterDelete string `yaml:"onHeadquarterDelete"`
+}
+
+// JWTConfig verifies bearer tokens signed with Secret (HS256) or a key from
+// JWKSFile (RS256). The ReadScope and WriteScope values of the ScopeClaim
+// grant models.ScopeRead and models.ScopeWrite.
+type JWTConfig struct {
+	Enabled    bool   `yaml:"enabled"`
+	Secret     string `yaml:"secret"`
+	JWKSFile   string `yaml:"jwksFile"`
+	Issuer     string `yaml:"issuer"`
+	Audience   string `yaml:"audience"`
+	ScopeClaim string `yaml:"scopeClaim"`
+	ReadScope  string `yaml:"readScope"`
+	WriteScope string `yaml:"writeScope"`
+}
+
+// AuthConfig selects how requests authenticate: API keys kept in the
+// database, JWTs, or both.
+type AuthConfig struct {
+	Enabled bool      `yaml:"enabled"`
+	ApiKeys bool      `yaml:"apiKeys"`
+	JWT     JWTConfig `yaml:"jwt"`
+}
+
+// RateLimit is a token bucket holding up to Burst requests, refilled with
+// Rate requests per second.
+type RateLimit struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+// RateLimitConfig limits each API key, token subject or client IP separately
+// in every route group.
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// IP covers every request of a client IP, checked before its credentials
+	// so invalid ones can't be tried without limit.
+	IP RateLimit `yaml:"ip"`
+	// Read covers single swift codes and their history.
+	Read RateLimit `yaml:"read"`
+	// List covers whole countries, search and export.
+	List  RateLimit `yaml:"list"`
+	Write RateLimit `yaml:"write"`
+}
+
+// CacheConfig keeps up to Size lookups of the postgres repository in memory
+// for TTL.
+type CacheConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	Size    int           `yaml:"size"`
+	TTL     time.Duration `yaml:"ttl"`
+}
+
+// MetricsConfig serves Prometheus metrics on Path, outside authentication and
+// rate limiting.
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path"`
+}
+
+const (
+	TracingExporterStdout = "stdout"
+	TracingExporterOTLP   = "otlp"
+)
+
+// TracingConfig exports OpenTelemetry spans to stdout, or over OTLP/HTTP to
+// Endpoint. An empty Endpoint leaves it to the OTEL_EXPORTER_OTLP_* variables.
+type TracingConfig struct {
+	Enabled     bool    `yaml:"enabled"`
+	Exporter    string  `yaml:"exporter"`
+	Endpoint    string  `yaml:"endpoint"`
+	ServiceName string  `yaml:"serviceName"`
+	SampleRatio float64 `yaml:"sampleRatio"`
+}
+
+// LogConfig sets the lowest level logged: debug, info, warn or error.
+type LogConfig struct {
+	Level string `yaml:"level"`
+}
+
+// SlogLevel returns Level as a slog.Level. Validate rejects unknown levels.
+func (config LogConfig) SlogLevel() slog.Level {
+	var level slog.Level
+	_ = level.UnmarshalText([]byte(config.Level))
+	return level
+}
+
 type Config struct {
-	DBConfig dbs.Config
+	Server     ServerConfig    `yaml:"server"`
+	DBConfig   dbs.Config      `yaml:"db"`
+	Import     ImportConfig    `yaml:"import"`
+	Features   FeaturesConfig  `yaml:"features"`
+	Branches   BranchesConfig  `yaml:"branches"`
+	Auth       AuthConfig      `yaml:"auth"`
+	RateLimit  RateLimitConfig `yaml:"rateLimit"`
+	Cache      CacheConfig     `yaml:"cache"`
+	Metrics    MetricsConfig   `yaml:"metrics"`
+	Tracing    TracingConfig   `yaml:"tracing"`
+	Log        LogConfig       `yaml:"log"`
+	Repository string          `yaml:"repository"`
 }
 
-func GetConfig() *Config {
-	config := Config{
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Address:           ":8080",
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      5 * time.Minute,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownTimeout:   15 * time.Second,
+		},
 		DBConfig: dbs.Config{
-			Host:     os.Getenv("DB_HOST"),
-			Port:     os.Getenv("DB_PORT"),
-			User:     os.Getenv("DB_USER"),
-			Password: os.Getenv("DB_PASSWORD"),
-			Database: os.Getenv("DB_NAME"),
-			SSLMode:  os.Getenv("DB_SSLMODE"),
+			Port:            "5432",
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: 5 * time.Minute,
+			ConnectTimeout:  5 * time.Second,
+		},
+		Import: ImportConfig{
+			Path:      "./data.csv",
+			OnStartup: true,
+		},
+		Features: FeaturesConfig{
+			Search: true,
+			Export: true,
+			Bulk:   true,
+		},
+		Branches: BranchesConfig{
+			MissingHeadquarter:  models.MissingHeadquarterFlag,
+			OnHeadquarterDelete: models.HeadquarterDeleteOrphan,
+		},
+		Auth: AuthConfig{
+			Enabled: true,
+			ApiKeys: true,
+			JWT: JWTConfig{
+				ScopeClaim: "scope",
+				ReadScope:  "swift-codes:read",
+				WriteScope: "swift-codes:write",
+			},
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			IP:      RateLimit{Rate: 50, Burst: 100},
+			Read:    RateLimit{Rate: 20, Burst: 40},
+			List:    RateLimit{Rate: 1, Burst: 5},
+			Write:   RateLimit{Rate: 5, Burst: 10},
+		},
+		Cache: CacheConfig{
+			Enabled: true,
+			Size:    10000,
+			TTL:     5 * time.Minute,
 		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+			Path:    "/metrics",
+		},
+		Tracing: TracingConfig{
+			Exporter:    TracingExporterStdout,
+			ServiceName: "swift-codes",
+			SampleRatio: 1,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		Repository: RepositoryPostgres,
+	}
+}
+
+// Load builds the config from the defaults, the YAML file at path (or $CONFIG_FILE)
+// and environment variable overrides, in that order, and validates the result.
+func Load(path string) (*Config, error) {
+	config := Default()
+
+	if path == "" {
+		path = os.Getenv(ConfigFileEnv)
+	}
+	if path != "" {
+		if err := config.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := config.loadEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (config *Config) loadFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	return nil
+}
+
+type envOverride struct {
+	name string
+	set  func(value string) error
+}
+
+func stringEnv(name string, field *string) envOverride {
+	return envOverride{name: name, set: func(value string) error {
+		*field = value
+		return nil
+	}}
+}
+
+func intEnv(name string, field *int) envOverride {
+	return envOverride{name: name, set: func(value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s must be an integer, got %q", name, value)
+		}
+		*field = parsed
+		return nil
+	}}
+}
+
+func floatEnv(name string, field *float64) envOverride {
+	return envOverride{name: name, set: func(value string) error {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%s must be a number, got %q", name, value)
+		}
+		*field = parsed
+		return nil
+	}}
+}
+
+// listEnv splits a comma separated value.
+func listEnv(name string, field *[]string) envOverride {
+	return envOverride{name: name, set: func(value string) error {
+		*field = nil
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*field = append(*field, item)
+			}
+		}
+		return nil
+	}}
+}
+
+func boolEnv(name string, field *bool) envOverride {
+	return envOverride{name: name, set: func(value string) error {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s must be a boolean, got %q", name, value)
+		}
+		*field = parsed
+		return nil
+	}}
+}
+
+func durationEnv(name string, field *time.Duration) envOverride {
+	return envOverride{name: name, set: func(value string) error {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%s must be a duration such as 30s, got %q", name, value)
+		}
+		*field = parsed
+		return nil
+	}}
+}
+
+func (config *Config) envOverrides() []envOverride {
+	return []envOverride{
+		stringEnv("SERVER_ADDRESS", &config.Server.Address),
+		durationEnv("SERVER_READ_TIMEOUT", &config.Server.ReadTimeout),
+		durationEnv("SERVER_READ_HEADER_TIMEOUT", &config.Server.ReadHeaderTimeout),
+		durationEnv("SERVER_WRITE_TIMEOUT", &config.Server.WriteTimeout),
+		durationEnv("SERVER_IDLE_TIMEOUT", &config.Server.IdleTimeout),
+		durationEnv("SERVER_SHUTDOWN_TIMEOUT", &config.Server.ShutdownTimeout),
+		listEnv("SERVER_TRUSTED_PROXIES", &config.Server.TrustedProxies),
+
+		stringEnv("DB_HOST", &config.DBConfig.Host),
+		stringEnv("DB_PORT", &config.DBConfig.Port),
+		stringEnv("DB_USER", &config.DBConfig.User),
+		stringEnv("DB_PASSWORD", &config.DBConfig.Password),
+		stringEnv("DB_NAME", &config.DBConfig.Database),
+		stringEnv("DB_SSLMODE", &config.DBConfig.SSLMode),
+		intEnv("DB_MAX_OPEN_CONNS", &config.DBConfig.MaxOpenConns),
+		intEnv("DB_MAX_IDLE_CONNS", &config.DBConfig.MaxIdleConns),
+		durationEnv("DB_CONN_MAX_LIFETIME", &config.DBConfig.ConnMaxLifetime),
+		durationEnv("DB_CONNECT_TIMEOUT", &config.DBConfig.ConnectTimeout),
+
+		stringEnv("IMPORT_PATH", &config.Import.Path),
+		boolEnv("IMPORT_ON_STARTUP", &config.Import.OnStartup),
+		boolEnv("IMPORT_PRUNE", &config.Import.Prune),
+
+		boolEnv("FEATURE_SEARCH", &config.Features.Search),
+		boolEnv("FEATURE_EXPORT", &config.Features.Export),
+		boolEnv("FEATURE_BULK", &config.Features.Bulk),
+
+		stringEnv("BRANCHES_MISSING_HEADQUARTER", &config.Branches.MissingHeadquarter),
+		stringEnv("BRANCHES_ON_HEADQUARTER_DELETE", &config.Branches.OnHeadquarterDelete),
+
+		boolEnv("AUTH_ENABLED", &config.Auth.Enabled),
+		boolEnv("AUTH_API_KEYS", &config.Auth.ApiKeys),
+		boolEnv("AUTH_JWT_ENABLED", &config.Auth.JWT.Enabled),
+		stringEnv("AUTH_JWT_SECRET", &config.Auth.JWT.Secret),
+		stringEnv("AUTH_JWT_JWKS_FILE", &config.Auth.JWT.JWKSFile),
+		stringEnv("AUTH_JWT_ISSUER", &config.Auth.JWT.Issuer),
+		stringEnv("AUTH_JWT_AUDIENCE", &config.Auth.JWT.Audience),
+		stringEnv("AUTH_JWT_SCOPE_CLAIM", &config.Auth.JWT.ScopeClaim),
+		stringEnv("AUTH_JWT_READ_SCOPE", &config.Auth.JWT.ReadScope),
+		stringEnv("AUTH_JWT_WRITE_SCOPE", &config.Auth.JWT.WriteScope),
+
+		boolEnv("RATE_LIMIT_ENABLED", &config.RateLimit.Enabled),
+		floatEnv("RATE_LIMIT_IP_RATE", &config.RateLimit.IP.Rate),
+		intEnv("RATE_LIMIT_IP_BURST", &config.RateLimit.IP.Burst),
+		floatEnv("RATE_LIMIT_READ_RATE", &config.RateLimit.Read.Rate),
+		intEnv("RATE_LIMIT_READ_BURST", &config.RateLimit.Read.Burst),
+		floatEnv("RATE_LIMIT_LIST_RATE", &config.RateLimit.List.Rate),
+		intEnv("RATE_LIMIT_LIST_BURST", &config.RateLimit.List.Burst),
+		floatEnv("RATE_LIMIT_WRITE_RATE", &config.RateLimit.Write.Rate),
+		intEnv("RATE_LIMIT_WRITE_BURST", &config.RateLimit.Write.Burst),
+
+		boolEnv("CACHE_ENABLED", &config.Cache.Enabled),
+		intEnv("CACHE_SIZE", &config.Cache.Size),
+		durationEnv("CACHE_TTL", &config.Cache.TTL),
+
+		boolEnv("METRICS_ENABLED", &config.Metrics.Enabled),
+		stringEnv("METRICS_PATH", &config.Metrics.Path),
+
+		boolEnv("TRACING_ENABLED", &config.Tracing.Enabled),
+		stringEnv("TRACING_EXPORTER", &config.Tracing.Exporter),
+		stringEnv("TRACING_ENDPOINT", &config.Tracing.Endpoint),
+		stringEnv("TRACING_SERVICE_NAME", &config.Tracing.ServiceName),
+		floatEnv("TRACING_SAMPLE_RATIO", &config.Tracing.SampleRatio),
+
+		stringEnv("LOG_LEVEL", &config.Log.Level),
+
+		stringEnv("SWIFT_REPOSITORY", &config.Repository),
+	}
+}
+
+// loadEnv applies every set, non-empty environment variable on top of the current values.
+func (config *Config) loadEnv(lookup func(string) (string, bool)) error {
+	var errs []error
+	for _, override := range config.envOverrides() {
+		value, ok := lookup(override.name)
+		if !ok || value == "" {
+			continue
+		}
+		if err := override.set(value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid environment: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// Validate reports every invalid setting at once, one per line.
+func (config *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	if _, _, err := net.SplitHostPort(config.Server.Address); err != nil {
+		errs = append(errs, fmt.Errorf("server.address must be host:port, got %q", config.Server.Address))
+	}
+	check(config.Server.ReadTimeout >= 0, "server.readTimeout must not be negative")
+	check(config.Server.ReadHeaderTimeout >= 0, "server.readHeaderTimeout must not be negative")
+	check(config.Server.WriteTimeout >= 0, "server.writeTimeout must not be negative")
+	check(config.Server.IdleTimeout >= 0, "server.idleTimeout must not be negative")
+	check(config.Server.ShutdownTimeout > 0, "server.shutdownTimeout must be positive")
+	for _, proxy := range config.Server.TrustedProxies {
+		check(validProxy(proxy), "server.trustedProxies must be IP addresses or CIDR ranges, got %q", proxy)
+	}
+
+	switch config.Repository {
+	case RepositoryMemory:
+		check(!config.Auth.Enabled || !config.Auth.ApiKeys, "auth.apiKeys requires the %s repository, disable it to use %s", RepositoryPostgres, RepositoryMemory)
+	case RepositoryPostgres:
+		db := config.DBConfig
+		check(db.Host != "", "db.host is required")
+		port, err := strconv.Atoi(db.Port)
+		check(err == nil && port > 0 && port < 65536, "db.port must be a number between 1 and 65535, got %q", db.Port)
+		check(db.User != "", "db.user is required")
+		check(db.Database != "", "db.database is required")
+		check(slices.Contains(sslModes, db.SSLMode), "db.sslMode must be one of: %s", strings.Join(sslModes, ", "))
+		check(db.MaxOpenConns > 0, "db.maxOpenConns must be positive")
+		check(db.MaxIdleConns >= 0 && db.MaxIdleConns <= db.MaxOpenConns, "db.maxIdleConns must be between 0 and db.maxOpenConns")
+		check(db.ConnMaxLifetime >= 0, "db.connMaxLifetime must not be negative")
+		check(db.ConnectTimeout > 0, "db.connectTimeout must be positive")
+	default:
+		errs = append(errs, fmt.Errorf("repository must be one of: %s, %s, got %q", RepositoryPostgres, RepositoryMemory, config.Repository))
+	}
+
+	if config.Auth.Enabled {
+		check(config.Auth.ApiKeys || config.Auth.JWT.Enabled, "auth.enabled requires auth.apiKeys or auth.jwt.enabled")
+	}
+	if config.Auth.Enabled && config.Auth.JWT.Enabled {
+		jwt := config.Auth.JWT
+		check(jwt.Secret != "" || jwt.JWKSFile != "", "auth.jwt requires a secret or a jwksFile")
+		check(jwt.Secret == "" || len(jwt.Secret) >= minJWTSecretLength, "auth.jwt.secret must be at least %d bytes", minJWTSecretLength)
+		check(jwt.ScopeClaim != "", "auth.jwt.scopeClaim is required")
+		check(jwt.ReadScope != "" && jwt.WriteScope != "", "auth.jwt.readScope and auth.jwt.writeScope are required")
+	}
+
+	if config.RateLimit.Enabled {
+		limits := []struct {
+			name  string
+			limit RateLimit
+		}{
+			{"ip", config.RateLimit.IP},
+			{"read", config.RateLimit.Read},
+			{"list", config.RateLimit.List},
+			{"write", config.RateLimit.Write},
+		}
+		for _, group := range limits {
+			check(group.limit.Rate > 0, "rateLimit.%s.rate must be positive", group.name)
+			check(group.limit.Burst >= 1, "rateLimit.%s.burst must be at least 1", group.name)
+		}
+	}
+
+	if config.Cache.Enabled {
+		check(config.Cache.Size > 0, "cache.size must be positive")
+		check(config.Cache.TTL > 0, "cache.ttl must be positive")
+	}
+
+	if config.Metrics.Enabled {
+		check(strings.HasPrefix(config.Metrics.Path, "/") && !strings.HasPrefix(config.Metrics.Path, "/v1/"),
+			"metrics.path must start with / and be outside /v1/, got %q", config.Metrics.Path)
+	}
+
+	if config.Tracing.Enabled {
+		check(config.Tracing.Exporter == TracingExporterStdout || config.Tracing.Exporter == TracingExporterOTLP,
+			"tracing.exporter must be one of: %s, %s, got %q", TracingExporterStdout, TracingExporterOTLP, config.Tracing.Exporter)
+		check(config.Tracing.ServiceName != "", "tracing.serviceName is required")
+		check(config.Tracing.SampleRatio >= 0 && config.Tracing.SampleRatio <= 1, "tracing.sampleRatio must be between 0 and 1")
+	}
+
+	var level slog.Level
+	check(level.UnmarshalText([]byte(config.Log.Level)) == nil,
+		"log.level must be one of: debug, info, warn, error, got %q", config.Log.Level)
+
+	check(!config.Import.OnStartup || config.Import.Path != "", "import.path is required when import.onStartup is enabled")
+
+	check(models.IsValidMissingHeadquarterPolicy(config.Branches.MissingHeadquarter),
+		"branches.missingHeadquarter must be one of: %s, %s, got %q",
+		models.MissingHeadquarterFlag, models.MissingHeadquarterReject, config.Branches.MissingHeadquarter)
+	check(models.IsValidHeadquarterDeletePolicy(config.Branches.OnHeadquarterDelete),
+		"branches.onHeadquarterDelete must be one of: %s, %s, %s, got %q",
+		models.HeadquarterDeleteBlock, models.HeadquarterDeleteCascade, models.HeadquarterDeleteOrphan, config.Branches.OnHeadquarterDelete)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func validProxy(proxy string) bool {
+	if _, _, err := net.ParseCIDR(proxy); err == nil {
+		return true
+	}
+	return net.ParseIP(proxy) != nil
+}
+
+// Masked returns a copy of the config that is safe to print or log.
+func (config Config) Masked() Config {
+	if config.DBConfig.Password != "" {
+		config.DBConfig.Password = maskedSecret
+	}
+	if config.Auth.JWT.Secret != "" {
+		config.Auth.JWT.Secret = maskedSecret
+	}
+	return config
+}
+
+// String renders the effective config as YAML with secrets masked.
+func (config Config) String() string {
+	content, err := yaml.Marshal(config.Masked())
+	if err != nil {
+		return err.Error()
 	}
-	return &config
+	return string(content)
 }
@@ -0,0 +1,185 @@
+package configs
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte(content), 0o644)
+	assert.NoError(t, err)
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeConfigFile(t, `
+server:
+  address: ":9090"
+  writeTimeout: 1m
+db:
+  host: filehost
+  user: swift
+  password: secret
+  database: swifts
+  maxOpenConns: 10
+  maxIdleConns: 5
+features:
+  export: false
+`)
+	t.Setenv("DB_HOST", "envhost")
+	t.Setenv("IMPORT_ON_STARTUP", "false")
+	t.Setenv("SERVER_TRUSTED_PROXIES", "10.0.0.1, 192.168.0.0/16")
+	t.Setenv("RATE_LIMIT_LIST_RATE", "0.5")
+
+	config, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, ":9090", config.Server.Address)
+	assert.Equal(t, time.Minute, config.Server.WriteTimeout)
+	assert.Equal(t, 5*time.Second, config.Server.ReadHeaderTimeout)
+	assert.Equal(t, "envhost", config.DBConfig.Host)
+	assert.Equal(t, "5432", config.DBConfig.Port)
+	assert.Equal(t, 10, config.DBConfig.MaxOpenConns)
+	assert.False(t, config.Import.OnStartup)
+	assert.Equal(t, FeaturesConfig{Search: true, Export: false, Bulk: true}, config.Features)
+	assert.Equal(t, RepositoryPostgres, config.Repository)
+	assert.Equal(t, []string{"10.0.0.1", "192.168.0.0/16"}, config.Server.TrustedProxies)
+	assert.Equal(t, RateLimit{Rate: 0.5, Burst: 5}, config.RateLimit.List)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		env     map[string]string
+		wantErr []string
+	}{
+		{
+			name:    "unknown field",
+			file:    "server:\n  port: 8080\n",
+			wantErr: []string{"field port not found"},
+		},
+		{
+			name:    "invalid env value",
+			env:     map[string]string{"SERVER_READ_TIMEOUT": "soon", "DB_MAX_OPEN_CONNS": "many"},
+			wantErr: []string{`SERVER_READ_TIMEOUT must be a duration such as 30s, got "soon"`, `DB_MAX_OPEN_CONNS must be an integer, got "many"`},
+		},
+		{
+			name: "invalid values",
+			file: "server:\n  address: localhost\ndb:\n  port: abc\n  sslMode: maybe\n  maxIdleConns: 50\n",
+			wantErr: []string{
+				`server.address must be host:port, got "localhost"`,
+				"db.host is required",
+				`db.port must be a number between 1 and 65535, got "abc"`,
+				"db.user is required",
+				"db.database is required",
+				"db.sslMode must be one of",
+				"db.maxIdleConns must be between 0 and db.maxOpenConns",
+			},
+		},
+		{
+			name:    "unknown branch policy",
+			env:     map[string]string{"SWIFT_REPOSITORY": "memory", "BRANCHES_ON_HEADQUARTER_DELETE": "ignore"},
+			wantErr: []string{`branches.onHeadquarterDelete must be one of: block, cascade, orphan, got "ignore"`},
+		},
+		{
+			name: "invalid jwt",
+			env:  map[string]string{"SWIFT_REPOSITORY": "memory", "AUTH_API_KEYS": "false", "AUTH_JWT_ENABLED": "true", "AUTH_JWT_SCOPE_CLAIM": ""},
+			file: "auth:\n  jwt:\n    scopeClaim: ''\n",
+			wantErr: []string{
+				"auth.jwt requires a secret or a jwksFile",
+				"auth.jwt.scopeClaim is required",
+			},
+		},
+		{
+			name:    "no authentication method",
+			env:     map[string]string{"SWIFT_REPOSITORY": "memory", "AUTH_API_KEYS": "false"},
+			wantErr: []string{"auth.enabled requires auth.apiKeys or auth.jwt.enabled"},
+		},
+		{
+			name:    "short jwt secret",
+			env:     map[string]string{"SWIFT_REPOSITORY": "memory", "AUTH_API_KEYS": "false", "AUTH_JWT_ENABLED": "true", "AUTH_JWT_SECRET": "short"},
+			wantErr: []string{"auth.jwt.secret must be at least 32 bytes"},
+		},
+		{
+			name: "invalid rate limit, cache and metrics",
+			env:  map[string]string{"SWIFT_REPOSITORY": "memory", "AUTH_ENABLED": "false", "RATE_LIMIT_IP_BURST": "0", "RATE_LIMIT_LIST_RATE": "0", "RATE_LIMIT_WRITE_BURST": "0", "SERVER_TRUSTED_PROXIES": "10.0.0.0/8, proxy", "CACHE_SIZE": "0", "METRICS_PATH": "/v1/metrics"},
+			wantErr: []string{
+				"cache.size must be positive",
+				`metrics.path must start with / and be outside /v1/, got "/v1/metrics"`,
+				"rateLimit.ip.burst must be at least 1",
+				"rateLimit.list.rate must be positive",
+				"rateLimit.write.burst must be at least 1",
+				`server.trustedProxies must be IP addresses or CIDR ranges, got "proxy"`,
+			},
+		},
+		{
+			name: "invalid tracing and log level",
+			env:  map[string]string{"SWIFT_REPOSITORY": "memory", "AUTH_ENABLED": "false", "TRACING_ENABLED": "true", "TRACING_EXPORTER": "jaeger", "TRACING_SAMPLE_RATIO": "2", "LOG_LEVEL": "verbose"},
+			wantErr: []string{
+				`tracing.exporter must be one of: stdout, otlp, got "jaeger"`,
+				"tracing.sampleRatio must be between 0 and 1",
+				`log.level must be one of: debug, info, warn, error, got "verbose"`,
+			},
+		},
+		{
+			name:    "unknown repository",
+			env:     map[string]string{"SWIFT_REPOSITORY": "redis"},
+			wantErr: []string{`repository must be one of: postgres, memory, got "redis"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"DB_HOST", "DB_USER", "DB_NAME", "DB_PORT", "DB_SSLMODE", "SWIFT_REPOSITORY", "AUTH_ENABLED", ConfigFileEnv} {
+				t.Setenv(name, "")
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			path := ""
+			if tt.file != "" {
+				path = writeConfigFile(t, tt.file)
+			}
+
+			_, err := Load(path)
+			assert.Error(t, err)
+			for _, want := range tt.wantErr {
+				assert.ErrorContains(t, err, want)
+			}
+		})
+	}
+}
+
+func TestValidate_Memory(t *testing.T) {
+	config := Default()
+	config.Repository = RepositoryMemory
+	config.Auth.Enabled = false
+
+	assert.NoError(t, config.Validate())
+
+	config.Auth.Enabled = true
+	assert.ErrorContains(t, config.Validate(), "auth.apiKeys requires the postgres repository")
+
+	config.Auth.ApiKeys = false
+	config.Auth.JWT.Enabled = true
+	config.Auth.JWT.JWKSFile = "jwks.json"
+	assert.NoError(t, config.Validate())
+}
+
+func TestConfig_String(t *testing.T) {
+	config := Default()
+	config.DBConfig.Password = "secret"
+	config.Auth.JWT.Secret = "jwt-signing-key"
+
+	printed := config.String()
+	assert.NotContains(t, printed, "password: secret")
+	assert.NotContains(t, printed, "jwt-signing-key")
+	assert.Contains(t, printed, "secret: '"+maskedSecret+"'")
+	assert.Contains(t, printed, "password: '"+maskedSecret+"'")
+	assert.Contains(t, printed, "writeTimeout: 5m0s")
+	assert.Equal(t, "secret", config.DBConfig.Password)
+}
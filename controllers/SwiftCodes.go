@@ -2,123 +2,550 @@ package controllers
 
 import (
 	"awesomeProject/customErrors"
+	"awesomeProject/logging"
 	"awesomeProject/models"
 	"awesomeProject/repositories"
 	"awesomeProject/services"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
+	"io"
+	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Controller struct {
 	SwiftRepo    repositories.SwiftRepo
 	Validate     models.SwiftValidator
 	SwiftService services.SwiftService
+	// Logger logs the errors the client can't fix, slog.Default() when nil.
+	Logger *slog.Logger
 }
 
-func handleError(c *gin.Context, err error) {
-	var httpErr *customErrors.HttpError
-	if errors.As(err, &httpErr) {
-		httpErr.Send(c)
-		return
+// handleError sends the problem for err, logging the errors that end up as
+// 500 Internal Server Error.
+func (controller Controller) handleError(c *gin.Context, err error) {
+	problem := customErrors.NewProblem(err)
+	if problem.Status >= http.StatusInternalServerError {
+		logging.OrDefault(controller.Logger).ErrorContext(c.Request.Context(), "request failed", "error", err)
+	}
+	problem.Send(c)
+}
+
+func bindSwift(c *gin.Context) (*models.Swift, error) {
+	var swift models.Swift
+	err := c.ShouldBindJSON(&swift)
+	if err != nil {
+		var typeError *json.UnmarshalTypeError
+		if errors.As(err, &typeError) {
+			return nil, typeError
+		}
+		return nil, customErrors.ErrBadRequest
+	}
+	return &swift, nil
+}
+
+func bindPageRequest(c *gin.Context) (models.PageRequest, error) {
+	page := models.PageRequest{
+		After:  c.Query("after"),
+		SortBy: c.DefaultQuery("sort", models.SortBySwiftCode),
+	}
+
+	if limit, ok := c.GetQuery("limit"); ok {
+		var err error
+		page.Limit, err = strconv.Atoi(limit)
+		if err != nil || page.Limit < 1 || page.Limit > models.MaxPageLimit {
+			return page, customErrors.ErrInvalidPageLimit
+		}
+	}
+
+	if !models.IsValidSortBy(page.SortBy) {
+		return page, customErrors.ErrInvalidSortBy
+	}
+
+	if headquarterMissing, ok := c.GetQuery("headquarterMissing"); ok {
+		var err error
+		page.HeadquarterMissing, err = strconv.ParseBool(headquarterMissing)
+		if err != nil {
+			return page, customErrors.ErrInvalidHeadquarterMissing
+		}
+	}
+
+	return page, nil
+}
+
+// resourceVersion names the version of a response in its ETag and
+// Last-Modified headers.
+type resourceVersion interface {
+	ETag() string
+	LastModified() time.Time
+	MatchesIfNoneMatch(header string) bool
+}
+
+// respondConditionally sends body with the validators of version, or just
+// 304 if the client's copy, named by If-None-Match or else dated by
+// If-Modified-Since, is still current.
+func respondConditionally(c *gin.Context, version resourceVersion, body any) {
+	lastModified := version.LastModified()
+	c.Header("Cache-Control", "no-cache")
+	c.Header("ETag", version.ETag())
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
 	}
-	var validationErr validator.ValidationErrors
-	if errors.As(err, &validationErr) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": strings.Split(validationErr.Error(), "\n"),
-		})
+
+	notModified := false
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" {
+		notModified = version.MatchesIfNoneMatch(ifNoneMatch)
+	} else if ifModifiedSince, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil && !lastModified.IsZero() {
+		notModified = !lastModified.Truncate(time.Second).After(ifModifiedSince)
+	}
+
+	if notModified {
+		c.Status(http.StatusNotModified)
 		return
 	}
-	customErrors.ErrUnknown.Send(c)
+	c.JSON(http.StatusOK, body)
 }
 
 func (controller Controller) GetSwiftDetails(c *gin.Context) {
 	ctx := c.Request.Context()
 	swiftCode := c.Param("swiftCode")
-	swift, branches, err := controller.SwiftService.GetSwiftDetails(ctx, swiftCode, controller.SwiftRepo)
+	version, err := controller.SwiftService.GetSwiftVersion(ctx, swiftCode, controller.SwiftRepo)
 	if err != nil {
-		handleError(c, err)
+		controller.handleError(c, err)
 		return
 	}
 
+	swift, branches, parent, err := controller.SwiftService.GetSwiftDetails(ctx, swiftCode, controller.SwiftRepo)
+	if err != nil {
+		controller.handleError(c, err)
+		return
+	}
+
+	response := gin.H{
+		"address":       swift.Address,
+		"bankName":      swift.BankName,
+		"codeType":      swift.CodeType,
+		"countryISO2":   swift.CountryIso2,
+		"countryName":   swift.CountryName,
+		"isHeadquarter": swift.IsHeadquarter,
+		"swiftCode":     swift.SwiftCode,
+		"timeZone":      swift.TimeZone,
+		"townName":      swift.TownName,
+	}
+
 	if models.IsSwiftCodeOfHeadquarter(swiftCode) {
-		c.JSON(http.StatusOK, gin.H{
-			"address":       swift.Address,
-			"bankName":      swift.BankName,
-			"countryISO2":   swift.CountryIso2,
-			"countryName":   swift.CountryName,
-			"isHeadquarter": swift.IsHeadquarter,
-			"swiftCode":     swift.SwiftCode,
-			"branches":      branches,
-		})
-	} else {
-		c.JSON(http.StatusOK, gin.H{
-			"address":       swift.Address,
-			"bankName":      swift.BankName,
-			"countryISO2":   swift.CountryIso2,
-			"countryName":   swift.CountryName,
-			"isHeadquarter": swift.IsHeadquarter,
-			"swiftCode":     swift.SwiftCode,
-		})
+		response["branches"] = branches
+	} else if parent != nil {
+		response["headquarter"] = parent.Headquarter
+		response["siblingBranchCount"] = parent.SiblingBranchCount
+	}
+	if swift.HeadquarterMissing {
+		response["headquarterMissing"] = true
 	}
+
+	respondConditionally(c, version, response)
 }
 
 func (controller Controller) GetSwiftsDetailsByCountryIso2Code(c *gin.Context) {
 	ctx := c.Request.Context()
 	countryIso2Code := c.Param("countryIso2Code")
 
-	countryName, swifts, err := controller.SwiftService.GetSwiftsDetailsByCountryIso2Code(ctx, countryIso2Code, controller.SwiftRepo)
+	page, err := bindPageRequest(c)
 	if err != nil {
-		handleError(c, err)
+		controller.handleError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{
+
+	version, err := controller.SwiftService.GetCountryVersion(ctx, countryIso2Code, controller.SwiftRepo)
+	if err != nil {
+		controller.handleError(c, err)
+		return
+	}
+
+	countryName, swifts, nextCursor, err := controller.SwiftService.GetSwiftsDetailsByCountryIso2Code(ctx, countryIso2Code, page, controller.SwiftRepo)
+	if err != nil {
+		controller.handleError(c, err)
+		return
+	}
+
+	response := gin.H{
 		"countryISO2": countryIso2Code,
 		"countryName": countryName,
 		"swiftCodes":  swifts,
+		"nextCursor":  nil,
+	}
+	if nextCursor != "" {
+		response["nextCursor"] = nextCursor
+	}
+
+	respondConditionally(c, version, response)
+}
+
+func (controller Controller) Search(c *gin.Context) {
+	ctx := c.Request.Context()
+	search := models.SearchQuery{
+		Query:   c.Query("q"),
+		Country: c.Query("country"),
+	}
+
+	if limit, ok := c.GetQuery("limit"); ok {
+		var err error
+		search.Limit, err = strconv.Atoi(limit)
+		if err != nil || search.Limit < 1 || search.Limit > models.MaxPageLimit {
+			controller.handleError(c, customErrors.ErrInvalidPageLimit)
+			return
+		}
+	}
+
+	if offset, ok := c.GetQuery("offset"); ok {
+		var err error
+		search.Offset, err = strconv.Atoi(offset)
+		if err != nil || search.Offset < 0 {
+			controller.handleError(c, customErrors.ErrInvalidPageOffset)
+			return
+		}
+	}
+
+	swifts, nextOffset, err := controller.SwiftService.Search(ctx, search, controller.SwiftRepo)
+	if err != nil {
+		controller.handleError(c, err)
+		return
+	}
+
+	response := gin.H{
+		"swiftCodes": swifts,
+		"nextOffset": nil,
+	}
+	if nextOffset > 0 {
+		response["nextOffset"] = nextOffset
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// exportFlushEvery is how many rows are buffered before flushing to the client.
+const exportFlushEvery = 100
+
+func (controller Controller) Export(c *gin.Context) {
+	ctx := c.Request.Context()
+	countryIso2Code := c.Query("country")
+
+	var write func(*models.Swift) error
+	var flush func() error
+	switch c.DefaultQuery("format", "csv") {
+	case "csv":
+		writer := csv.NewWriter(c.Writer)
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="swift-codes.csv"`)
+		write = func(swift *models.Swift) error {
+			return writer.Write(swift.CSVRecord())
+		}
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+		if err := writer.Write(models.CSVHeader); err != nil {
+			controller.handleError(c, err)
+			return
+		}
+	case "ndjson":
+		encoder := json.NewEncoder(c.Writer)
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Header("Content-Disposition", `attachment; filename="swift-codes.ndjson"`)
+		write = func(swift *models.Swift) error {
+			return encoder.Encode(swift)
+		}
+		flush = func() error { return nil }
+	default:
+		controller.handleError(c, customErrors.ErrInvalidExportFormat)
+		return
+	}
+
+	c.Status(http.StatusOK)
+
+	rows := 0
+	err := controller.SwiftService.ExportSwifts(ctx, countryIso2Code, controller.SwiftRepo, func(swift *models.Swift) error {
+		if err := write(swift); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushEvery == 0 {
+			if err := flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
 	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		// The status line is already sent, so the client only sees a truncated body.
+		logging.OrDefault(controller.Logger).ErrorContext(ctx, "export failed", "error", err, "rows", rows)
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	c.Writer.Flush()
 }
 
 func (controller Controller) AddSwift(c *gin.Context) {
 	ctx := c.Request.Context()
-	var swift models.Swift
-	err := c.ShouldBindJSON(&swift)
+	swift, err := bindSwift(c)
+	if err != nil {
+		controller.handleError(c, err)
+		return
+	}
 
+	err = controller.SwiftService.AddSwift(ctx, swift, controller.SwiftRepo, controller.Validate)
 	if err != nil {
-		var typeError *json.UnmarshalTypeError
-		if errors.As(err, &typeError) {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": typeError.Field + " should be " + typeError.Type.Name(),
-			})
+		controller.handleError(c, err)
+		return
+	}
+
+	response := gin.H{
+		"message": "Swift code added successfully",
+	}
+	if swift.HeadquarterMissing {
+		response["headquarterMissing"] = true
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+const maxBulkSize = 1000
+
+// maxBulkItemBytes is far more than a swift code takes in JSON; with
+// maxBulkSize it caps how much of a batch is read into memory.
+const maxBulkItemBytes = 4 << 10
+
+// decodeBulkBody splits a JSON array or NDJSON stream into raw items.
+func decodeBulkBody(c *gin.Context) ([]json.RawMessage, error) {
+	var items []json.RawMessage
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBulkSize*maxBulkItemBytes)
+	decoder := json.NewDecoder(c.Request.Body)
+
+	if c.ContentType() != "application/x-ndjson" {
+		if err := decoder.Decode(&items); err != nil {
+			return nil, bulkBodyError(err)
+		}
+		return items, nil
+	}
+
+	for {
+		var item json.RawMessage
+		err := decoder.Decode(&item)
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+		if err != nil {
+			return nil, bulkBodyError(err)
+		}
+		items = append(items, item)
+	}
+}
+
+func bulkBodyError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return customErrors.ErrBulkBodyTooLarge
+	}
+	return customErrors.ErrBadRequest
+}
+
+func (controller Controller) AddSwifts(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var atomic bool
+	switch c.DefaultQuery("mode", "atomic") {
+	case "atomic":
+		atomic = true
+	case "best-effort":
+		atomic = false
+	default:
+		controller.handleError(c, customErrors.ErrInvalidBulkMode)
+		return
+	}
+
+	items, err := decodeBulkBody(c)
+	if err != nil {
+		controller.handleError(c, err)
+		return
+	}
+	if len(items) == 0 {
+		controller.handleError(c, customErrors.ErrBulkEmpty)
+		return
+	}
+	if len(items) > maxBulkSize {
+		controller.handleError(c, customErrors.ErrBulkTooLarge)
+		return
+	}
+
+	itemErrs := make([]error, len(items))
+	swiftCodes := make([]string, len(items))
+	headquarterMissing := make([]bool, len(items))
+	swifts := make([]models.Swift, 0, len(items))
+	indexes := make([]int, 0, len(items))
+	for i, item := range items {
+		var swift models.Swift
+		if err := json.Unmarshal(item, &swift); err != nil {
+			var typeError *json.UnmarshalTypeError
+			if !errors.As(err, &typeError) {
+				err = customErrors.ErrBadRequest
+			}
+			itemErrs[i] = err
+			continue
+		}
+		swiftCodes[i] = strings.ToUpper(swift.SwiftCode)
+		swifts = append(swifts, swift)
+		indexes = append(indexes, i)
+	}
+
+	// With an item that can't be decoded nothing is added in atomic mode,
+	// but the others are still checked so every bad item is reported at once.
+	if atomic && len(swifts) < len(items) {
+		checkErrs := controller.SwiftService.CheckSwifts(ctx, swifts, controller.SwiftRepo, controller.Validate)
+		for j, i := range indexes {
+			itemErrs[i] = checkErrs[j]
+			if itemErrs[i] == nil {
+				itemErrs[i] = customErrors.ErrBulkAborted
+			}
+		}
+	} else {
+		serviceErrs, err := controller.SwiftService.AddSwifts(ctx, swifts, atomic, controller.SwiftRepo, controller.Validate)
+		if err != nil {
+			controller.handleError(c, err)
 			return
 		}
-		handleError(c, customErrors.ErrBadRequest)
+		for j, i := range indexes {
+			itemErrs[i] = serviceErrs[j]
+			headquarterMissing[i] = swifts[j].HeadquarterMissing
+		}
+	}
+
+	created := 0
+	results := make([]gin.H, len(items))
+	for i, itemErr := range itemErrs {
+		result := gin.H{
+			"index":     i,
+			"swiftCode": swiftCodes[i],
+			"status":    http.StatusCreated,
+			"message":   "Swift code added successfully",
+		}
+		if itemErr != nil {
+			problem := customErrors.NewProblem(itemErr)
+			result["status"] = problem.Status
+			result["code"] = problem.Code
+			result["message"] = problem.Detail
+			if len(problem.Errors) > 0 {
+				result["errors"] = problem.Errors
+			}
+		} else {
+			created++
+			if headquarterMissing[i] {
+				result["headquarterMissing"] = true
+			}
+		}
+		results[i] = result
+	}
+
+	status := http.StatusCreated
+	if created < len(items) {
+		status = http.StatusMultiStatus
+	}
+
+	c.JSON(status, gin.H{
+		"created": created,
+		"failed":  len(items) - created,
+		"results": results,
+	})
+}
+
+func (controller Controller) UpdateSwift(c *gin.Context) {
+	ctx := c.Request.Context()
+	swiftCode := c.Param("swiftCode")
+	swift, err := bindSwift(c)
+	if err != nil {
+		controller.handleError(c, err)
 		return
 	}
 
-	err = controller.SwiftService.AddSwift(ctx, &swift, controller.SwiftRepo, controller.Validate)
+	err = controller.SwiftService.UpdateSwift(ctx, swiftCode, swift, controller.SwiftRepo, controller.Validate)
 	if err != nil {
-		handleError(c, err)
-		fmt.Print(err)
+		controller.handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"message": "Swift code added successfully",
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Swift code updated successfully",
+	})
+}
+
+func (controller Controller) PatchSwift(c *gin.Context) {
+	ctx := c.Request.Context()
+	swiftCode := c.Param("swiftCode")
+	if c.ContentType() != "application/merge-patch+json" {
+		controller.handleError(c, customErrors.ErrUnsupportedMediaType)
+		return
+	}
+	patch, err := c.GetRawData()
+	if err != nil {
+		controller.handleError(c, customErrors.ErrBadRequest)
+		return
+	}
+
+	err = controller.SwiftService.PatchSwift(ctx, swiftCode, patch, controller.SwiftRepo, controller.Validate)
+	if err != nil {
+		controller.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Swift code updated successfully",
 	})
 }
 
 func (controller Controller) DeleteSwift(c *gin.Context) {
 	ctx := c.Request.Context()
 	swiftCode := c.Param("swiftCode")
-	err := controller.SwiftService.DeleteSwift(ctx, swiftCode, controller.SwiftRepo)
+	err := controller.SwiftService.DeleteSwift(ctx, swiftCode, c.GetHeader("If-Match"), controller.SwiftRepo)
 	if err != nil {
-		handleError(c, err)
+		controller.handleError(c, err)
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
+
+func (controller Controller) GetSwiftHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	swiftCode := strings.ToUpper(c.Param("swiftCode"))
+	history, err := controller.SwiftService.GetSwiftHistory(ctx, swiftCode, controller.SwiftRepo)
+	if err != nil {
+		controller.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"swiftCode": swiftCode,
+		"history":   history,
+	})
+}
+
+func (controller Controller) RestoreSwift(c *gin.Context) {
+	ctx := c.Request.Context()
+	swiftCode := c.Param("swiftCode")
+	err := controller.SwiftService.RestoreSwift(ctx, swiftCode, controller.SwiftRepo)
+	if err != nil {
+		controller.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Swift code restored successfully",
+	})
+}
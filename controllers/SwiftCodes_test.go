@@ -4,6 +4,8 @@ import (
 	"awesomeProject/customErrors"
 	"awesomeProject/mocks"
 	"awesomeProject/models"
+	"awesomeProject/repositories"
+	"context"
 	"encoding/json"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -12,8 +14,24 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
+// problemBody is the decoded problem sent for httpErr, with detail defaulting
+// to its message.
+func problemBody(httpErr *customErrors.HttpError, detail string) gin.H {
+	if detail == "" {
+		detail = httpErr.Message()
+	}
+	return gin.H{
+		"type":   "urn:swift-codes:problem:" + strings.ReplaceAll(strings.ToLower(httpErr.ErrorCode()), "_", "-"),
+		"title":  http.StatusText(httpErr.Code()),
+		"status": float64(httpErr.Code()),
+		"detail": detail,
+		"code":   httpErr.ErrorCode(),
+	}
+}
+
 func TestController_GetSwiftDetails(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -26,9 +44,12 @@ func TestController_GetSwiftDetails(t *testing.T) {
 		SwiftService: mockSwiftService,
 	}
 
+	updatedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
 	tests := []struct {
 		name           string
 		swiftCode      string
+		ifNoneMatch    string
 		mockSetup      func()
 		expectedStatus int
 		expectedBody   gin.H
@@ -45,10 +66,16 @@ func TestController_GetSwiftDetails(t *testing.T) {
 						CountryName:   "United States",
 						IsHeadquarter: true,
 						SwiftCode:     "ABCDEF12XXX",
+						CodeType:      "BIC11",
+						TownName:      "New York",
+						TimeZone:      "America/New_York",
 					},
 					[]models.SwiftMini{
 						{
 							SwiftCode:     "ABCDEF12346",
+							CodeType:      "BIC11",
+							TownName:      "New York",
+							TimeZone:      "America/New_York",
 							BankName:      "Branch 1",
 							CountryIso2:   "US",
 							IsHeadquarter: false,
@@ -56,6 +83,9 @@ func TestController_GetSwiftDetails(t *testing.T) {
 						},
 						{
 							SwiftCode:     "ABCDEF12347",
+							CodeType:      "BIC11",
+							TownName:      "New York",
+							TimeZone:      "America/New_York",
 							BankName:      "Branch 2",
 							CountryIso2:   "US",
 							IsHeadquarter: false,
@@ -63,6 +93,7 @@ func TestController_GetSwiftDetails(t *testing.T) {
 						},
 					},
 					nil,
+					nil,
 				)
 			},
 			expectedStatus: http.StatusOK,
@@ -73,9 +104,15 @@ func TestController_GetSwiftDetails(t *testing.T) {
 				"countryName":   "United States",
 				"isHeadquarter": true,
 				"swiftCode":     "ABCDEF12XXX",
+				"codeType":      "BIC11",
+				"townName":      "New York",
+				"timeZone":      "America/New_York",
 				"branches": []interface{}{
 					map[string]interface{}{
 						"swiftCode":     "ABCDEF12346",
+						"codeType":      "BIC11",
+						"townName":      "New York",
+						"timeZone":      "America/New_York",
 						"bankName":      "Branch 1",
 						"countryISO2":   "US",
 						"isHeadquarter": false,
@@ -83,6 +120,9 @@ func TestController_GetSwiftDetails(t *testing.T) {
 					},
 					map[string]interface{}{
 						"swiftCode":     "ABCDEF12347",
+						"codeType":      "BIC11",
+						"townName":      "New York",
+						"timeZone":      "America/New_York",
 						"bankName":      "Branch 2",
 						"countryISO2":   "US",
 						"isHeadquarter": false,
@@ -103,8 +143,24 @@ func TestController_GetSwiftDetails(t *testing.T) {
 						CountryName:   "United States",
 						IsHeadquarter: false,
 						SwiftCode:     "ABCDEF12346",
+						CodeType:      "BIC11",
+						TownName:      "New York",
+						TimeZone:      "America/New_York",
 					},
 					nil,
+					&models.BranchParent{
+						Headquarter: &models.SwiftMini{
+							SwiftCode:     "ABCDEF12XXX",
+							CodeType:      "BIC11",
+							TownName:      "New York",
+							TimeZone:      "America/New_York",
+							BankName:      "Bank of Test",
+							CountryIso2:   "US",
+							IsHeadquarter: true,
+							Address:       "123 Main St",
+						},
+						SiblingBranchCount: 1,
+					},
 					nil,
 				)
 			},
@@ -116,35 +172,108 @@ func TestController_GetSwiftDetails(t *testing.T) {
 				"countryName":   "United States",
 				"isHeadquarter": false,
 				"swiftCode":     "ABCDEF12346",
+				"codeType":      "BIC11",
+				"townName":      "New York",
+				"timeZone":      "America/New_York",
+				"headquarter": map[string]interface{}{
+					"swiftCode":     "ABCDEF12XXX",
+					"codeType":      "BIC11",
+					"townName":      "New York",
+					"timeZone":      "America/New_York",
+					"bankName":      "Bank of Test",
+					"countryISO2":   "US",
+					"isHeadquarter": true,
+					"address":       "123 Main St",
+				},
+				"siblingBranchCount": float64(1),
+			},
+		},
+		{
+			name:      "Success - Branch Without Headquarter",
+			swiftCode: "ABCDEF12346",
+			mockSetup: func() {
+				mockSwiftService.EXPECT().GetSwiftDetails(gomock.Any(), "ABCDEF12346", mockSwiftRepo).Return(
+					&models.Swift{
+						Address:       "456 Branch St",
+						BankName:      "Bank of Test Branch",
+						CountryIso2:   "US",
+						CountryName:   "United States",
+						IsHeadquarter: false,
+						SwiftCode:     "ABCDEF12346",
+						CodeType:      "BIC11",
+						TownName:      "New York",
+						TimeZone:      "America/New_York",
+					},
+					nil,
+					&models.BranchParent{},
+					nil,
+				)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: gin.H{
+				"address":            "456 Branch St",
+				"bankName":           "Bank of Test Branch",
+				"countryISO2":        "US",
+				"countryName":        "United States",
+				"isHeadquarter":      false,
+				"swiftCode":          "ABCDEF12346",
+				"codeType":           "BIC11",
+				"townName":           "New York",
+				"timeZone":           "America/New_York",
+				"headquarter":        nil,
+				"siblingBranchCount": float64(0),
+			},
+		},
+		{
+			name:        "Not modified",
+			swiftCode:   "ABCDEF12346",
+			ifNoneMatch: `W/"ABCDEF12346-1735787045000000000"`,
+			mockSetup: func() {
+				mockSwiftService.EXPECT().GetSwiftDetails(gomock.Any(), "ABCDEF12346", mockSwiftRepo).Return(
+					&models.Swift{SwiftCode: "ABCDEF12346"},
+					nil,
+					&models.BranchParent{},
+					nil,
+				)
 			},
+			expectedStatus: http.StatusNotModified,
 		},
 		{
 			name:      "Error - Swift not found",
 			swiftCode: "INVALIDCODE",
 			mockSetup: func() {
 				mockSwiftService.EXPECT().GetSwiftDetails(gomock.Any(), "INVALIDCODE", mockSwiftRepo).Return(
+					nil,
 					nil,
 					nil,
 					customErrors.ErrSwiftNotFound,
 				)
 			},
 			expectedStatus: http.StatusNotFound,
-			expectedBody:   gin.H{"message": "Swift not found"},
+			expectedBody:   problemBody(customErrors.ErrSwiftNotFound, ""),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			mockSwiftService.EXPECT().GetSwiftVersion(gomock.Any(), tt.swiftCode, mockSwiftRepo).Return(models.SwiftVersion{SwiftCode: tt.swiftCode, UpdatedAt: updatedAt}, nil)
 			tt.mockSetup()
 
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 			c.Request = httptest.NewRequest(http.MethodGet, "/swift/"+tt.swiftCode, nil)
+			if tt.ifNoneMatch != "" {
+				c.Request.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
 			c.Params = gin.Params{gin.Param{Key: "swiftCode", Value: tt.swiftCode}}
 
 			controller.GetSwiftDetails(c)
 
-			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedStatus, c.Writer.Status())
+			if tt.expectedStatus == http.StatusOK || tt.expectedStatus == http.StatusNotModified {
+				assert.Equal(t, `"`+tt.swiftCode+`-1735787045000000000"`, w.Header().Get("ETag"))
+				assert.Equal(t, "Thu, 02 Jan 2025 03:04:05 GMT", w.Header().Get("Last-Modified"))
+			}
 			if tt.expectedBody != nil {
 				var responseBody gin.H
 				err := json.Unmarshal(w.Body.Bytes(), &responseBody)
@@ -168,21 +297,27 @@ func TestController_GetSwiftsDetailsByCountryIso2Code(t *testing.T) {
 	}
 
 	tests := []struct {
-		name           string
-		countryIso2    string
-		mockSetup      func()
-		expectedStatus int
-		expectedBody   gin.H
+		name            string
+		countryIso2     string
+		query           string
+		ifModifiedSince string
+		mockSetup       func()
+		expectedStatus  int
+		expectedBody    gin.H
 	}{
 		{
 			name:        "Success",
 			countryIso2: "US",
 			mockSetup: func() {
-				mockSwiftService.EXPECT().GetSwiftsDetailsByCountryIso2Code(gomock.Any(), "US", mockSwiftRepo).Return(
+				mockSwiftService.EXPECT().GetCountryVersion(gomock.Any(), "US", mockSwiftRepo).Return(models.CountryVersion{CountryIso2: "US", Version: 1}, nil)
+				mockSwiftService.EXPECT().GetSwiftsDetailsByCountryIso2Code(gomock.Any(), "US", models.PageRequest{SortBy: models.SortBySwiftCode}, mockSwiftRepo).Return(
 					"United States",
 					[]models.SwiftMini{
 						{
 							SwiftCode:     "ABCDEF12XXX",
+							CodeType:      "BIC11",
+							TownName:      "New York",
+							TimeZone:      "America/New_York",
 							BankName:      "Bank of Test",
 							CountryIso2:   "US",
 							IsHeadquarter: true,
@@ -190,12 +325,16 @@ func TestController_GetSwiftsDetailsByCountryIso2Code(t *testing.T) {
 						},
 						{
 							SwiftCode:     "ABCDEF12346",
+							CodeType:      "BIC11",
+							TownName:      "New York",
+							TimeZone:      "America/New_York",
 							BankName:      "Bank of Test Branch",
 							CountryIso2:   "US",
 							IsHeadquarter: false,
 							Address:       "456 Branch St",
 						},
 					},
+					"",
 					nil,
 				)
 			},
@@ -203,9 +342,13 @@ func TestController_GetSwiftsDetailsByCountryIso2Code(t *testing.T) {
 			expectedBody: gin.H{
 				"countryISO2": "US",
 				"countryName": "United States",
+				"nextCursor":  nil,
 				"swiftCodes": []interface{}{
 					map[string]interface{}{
 						"swiftCode":     "ABCDEF12XXX",
+						"codeType":      "BIC11",
+						"townName":      "New York",
+						"timeZone":      "America/New_York",
 						"bankName":      "Bank of Test",
 						"countryISO2":   "US",
 						"isHeadquarter": true,
@@ -213,6 +356,9 @@ func TestController_GetSwiftsDetailsByCountryIso2Code(t *testing.T) {
 					},
 					map[string]interface{}{
 						"swiftCode":     "ABCDEF12346",
+						"codeType":      "BIC11",
+						"townName":      "New York",
+						"timeZone":      "America/New_York",
 						"bankName":      "Bank of Test Branch",
 						"countryISO2":   "US",
 						"isHeadquarter": false,
@@ -221,18 +367,139 @@ func TestController_GetSwiftsDetailsByCountryIso2Code(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:        "Success - Paginated by bank name",
+			countryIso2: "US",
+			query:       "?limit=1&after=ABCDEF12345&sort=bankName",
+			mockSetup: func() {
+				mockSwiftService.EXPECT().GetCountryVersion(gomock.Any(), "US", mockSwiftRepo).Return(models.CountryVersion{CountryIso2: "US", Version: 1}, nil)
+				mockSwiftService.EXPECT().GetSwiftsDetailsByCountryIso2Code(gomock.Any(), "US", models.PageRequest{
+					Limit:  1,
+					After:  "ABCDEF12345",
+					SortBy: models.SortByBankName,
+				}, mockSwiftRepo).Return(
+					"United States",
+					[]models.SwiftMini{
+						{
+							SwiftCode:   "ABCDEF12XXX",
+							BankName:    "Bank of Test",
+							CountryIso2: "US",
+						},
+					},
+					"ABCDEF12XXX",
+					nil,
+				)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: gin.H{
+				"countryISO2": "US",
+				"countryName": "United States",
+				"nextCursor":  "ABCDEF12XXX",
+				"swiftCodes": []interface{}{
+					map[string]interface{}{
+						"swiftCode":     "ABCDEF12XXX",
+						"codeType":      "",
+						"townName":      "",
+						"timeZone":      "",
+						"bankName":      "Bank of Test",
+						"countryISO2":   "US",
+						"isHeadquarter": false,
+						"address":       "",
+					},
+				},
+			},
+		},
+		{
+			name:           "Error - Invalid limit",
+			countryIso2:    "US",
+			query:          "?limit=0",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problemBody(customErrors.ErrInvalidPageLimit, ""),
+		},
+		{
+			name:           "Error - Invalid sort",
+			countryIso2:    "US",
+			query:          "?sort=address",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problemBody(customErrors.ErrInvalidSortBy, ""),
+		},
+		{
+			name:        "Success - Headquarter missing",
+			countryIso2: "US",
+			query:       "?headquarterMissing=true",
+			mockSetup: func() {
+				mockSwiftService.EXPECT().GetCountryVersion(gomock.Any(), "US", mockSwiftRepo).Return(models.CountryVersion{CountryIso2: "US", Version: 1}, nil)
+				mockSwiftService.EXPECT().GetSwiftsDetailsByCountryIso2Code(gomock.Any(), "US", models.PageRequest{
+					SortBy:             models.SortBySwiftCode,
+					HeadquarterMissing: true,
+				}, mockSwiftRepo).Return(
+					"United States",
+					[]models.SwiftMini{
+						{
+							SwiftCode:          "ABCDEF12001",
+							BankName:           "Bank of Test",
+							CountryIso2:        "US",
+							HeadquarterMissing: true,
+						},
+					},
+					"",
+					nil,
+				)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: gin.H{
+				"countryISO2": "US",
+				"countryName": "United States",
+				"nextCursor":  nil,
+				"swiftCodes": []interface{}{
+					map[string]interface{}{
+						"swiftCode":          "ABCDEF12001",
+						"codeType":           "",
+						"townName":           "",
+						"timeZone":           "",
+						"bankName":           "Bank of Test",
+						"countryISO2":        "US",
+						"isHeadquarter":      false,
+						"address":            "",
+						"headquarterMissing": true,
+					},
+				},
+			},
+		},
+		{
+			name:           "Error - Invalid headquarterMissing",
+			countryIso2:    "US",
+			query:          "?headquarterMissing=maybe",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problemBody(customErrors.ErrInvalidHeadquarterMissing, ""),
+		},
+		{
+			name:            "Not modified since",
+			countryIso2:     "US",
+			ifModifiedSince: "Thu, 02 Jan 2025 03:04:05 GMT",
+			mockSetup: func() {
+				mockSwiftService.EXPECT().GetCountryVersion(gomock.Any(), "US", mockSwiftRepo).Return(models.CountryVersion{CountryIso2: "US", Version: 2, UpdatedAt: time.Date(2025, 1, 2, 3, 4, 5, 500, time.UTC)}, nil)
+				mockSwiftService.EXPECT().GetSwiftsDetailsByCountryIso2Code(gomock.Any(), "US", gomock.Any(), mockSwiftRepo).Return("United States", nil, "", nil)
+			},
+			expectedStatus: http.StatusNotModified,
+		},
 		{
 			name:        "Error - Swift not found",
 			countryIso2: "INVALID",
 			mockSetup: func() {
-				mockSwiftService.EXPECT().GetSwiftsDetailsByCountryIso2Code(gomock.Any(), "INVALID", mockSwiftRepo).Return(
+				mockSwiftService.EXPECT().GetCountryVersion(gomock.Any(), "INVALID", mockSwiftRepo).Return(models.CountryVersion{CountryIso2: "INVALID", Version: 1}, nil)
+				mockSwiftService.EXPECT().GetSwiftsDetailsByCountryIso2Code(gomock.Any(), "INVALID", gomock.Any(), mockSwiftRepo).Return(
 					"",
 					nil,
+					"",
 					customErrors.ErrSwiftNotFound,
 				)
 			},
 			expectedStatus: http.StatusNotFound,
-			expectedBody:   gin.H{"message": "Swift not found"},
+			expectedBody:   problemBody(customErrors.ErrSwiftNotFound, ""),
 		},
 	}
 
@@ -242,12 +509,15 @@ func TestController_GetSwiftsDetailsByCountryIso2Code(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
-			c.Request = httptest.NewRequest(http.MethodGet, "/swift/country/"+tt.countryIso2, nil)
+			c.Request = httptest.NewRequest(http.MethodGet, "/swift/country/"+tt.countryIso2+tt.query, nil)
+			if tt.ifModifiedSince != "" {
+				c.Request.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
 			c.Params = gin.Params{gin.Param{Key: "countryIso2Code", Value: tt.countryIso2}}
 
 			controller.GetSwiftsDetailsByCountryIso2Code(c)
 
-			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedStatus, c.Writer.Status())
 			if tt.expectedBody != nil {
 				var responseBody gin.H
 				err := json.Unmarshal(w.Body.Bytes(), &responseBody)
@@ -295,13 +565,33 @@ func TestController_AddSwift(t *testing.T) {
 			expectedStatus: http.StatusCreated,
 			expectedBody:   gin.H{"message": "Swift code added successfully"},
 		},
+		{
+			name: "Success - Headquarter missing",
+			jsonBody: `{
+				"address": "123 Main St",
+				"bankName": "Bank of Test",
+				"countryIso2": "US",
+				"countryName": "United States",
+				"isHeadquarter": false,
+				"swiftCode": "ABCDEF12001"
+			}`,
+			mockSetup: func() {
+				mockSwiftService.EXPECT().AddSwift(gomock.Any(), gomock.Any(), mockSwiftRepo, mockValidator).
+					DoAndReturn(func(_ context.Context, swift *models.Swift, _ repositories.SwiftRepo, _ models.SwiftValidator) error {
+						swift.HeadquarterMissing = true
+						return nil
+					})
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   gin.H{"message": "Swift code added successfully", "headquarterMissing": true},
+		},
 		{
 			name:     "Error - Validation failed",
 			jsonBody: `dsadasdasdasd`,
 			mockSetup: func() {
 			},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   gin.H{"message": "Bad request"},
+			expectedBody:   problemBody(customErrors.ErrBadRequest, ""),
 		},
 		{
 			name: "Error - Validation failed",
@@ -316,7 +606,7 @@ func TestController_AddSwift(t *testing.T) {
 			mockSetup: func() {
 			},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   gin.H{"message": "isHeadquarter should be bool"},
+			expectedBody:   problemBody(customErrors.ErrInvalidFieldType, "isHeadquarter should be bool"),
 		},
 		{
 			name: "Error - Swift code already exists",
@@ -332,7 +622,7 @@ func TestController_AddSwift(t *testing.T) {
 				mockSwiftService.EXPECT().AddSwift(gomock.Any(), gomock.Any(), mockSwiftRepo, mockValidator).Return(customErrors.ErrSwiftCodeAlreadyExists)
 			},
 			expectedStatus: http.StatusConflict,
-			expectedBody:   gin.H{"message": "Swift code already exists"},
+			expectedBody:   problemBody(customErrors.ErrSwiftCodeAlreadyExists, ""),
 		},
 	}
 
@@ -372,6 +662,7 @@ func TestController_DeleteSwift(t *testing.T) {
 	tests := []struct {
 		name           string
 		swiftCode      string
+		ifMatch        string
 		mockSetup      func()
 		expectedStatus int
 	}{
@@ -379,15 +670,24 @@ func TestController_DeleteSwift(t *testing.T) {
 			name:      "Success",
 			swiftCode: "ABCDEF12XXX",
 			mockSetup: func() {
-				mockSwiftService.EXPECT().DeleteSwift(gomock.Any(), "ABCDEF12XXX", mockSwiftRepo).Return(nil)
+				mockSwiftService.EXPECT().DeleteSwift(gomock.Any(), "ABCDEF12XXX", "", mockSwiftRepo).Return(nil)
 			},
 			expectedStatus: http.StatusNoContent,
 		},
+		{
+			name:      "Error - Precondition failed",
+			swiftCode: "ABCDEF12XXX",
+			ifMatch:   `"ABCDEF12XXX-2"`,
+			mockSetup: func() {
+				mockSwiftService.EXPECT().DeleteSwift(gomock.Any(), "ABCDEF12XXX", `"ABCDEF12XXX-2"`, mockSwiftRepo).Return(customErrors.ErrPreconditionFailed)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
 		{
 			name:      "Error - Swift not found",
 			swiftCode: "INVALIDCODE",
 			mockSetup: func() {
-				mockSwiftService.EXPECT().DeleteSwift(gomock.Any(), "INVALIDCODE", mockSwiftRepo).Return(customErrors.ErrSwiftNotFound)
+				mockSwiftService.EXPECT().DeleteSwift(gomock.Any(), "INVALIDCODE", "", mockSwiftRepo).Return(customErrors.ErrSwiftNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 		},
@@ -400,6 +700,9 @@ func TestController_DeleteSwift(t *testing.T) {
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 			c.Request = httptest.NewRequest(http.MethodDelete, "/swift/"+tt.swiftCode, nil)
+			if tt.ifMatch != "" {
+				c.Request.Header.Set("If-Match", tt.ifMatch)
+			}
 			c.Params = gin.Params{gin.Param{Key: "swiftCode", Value: tt.swiftCode}}
 
 			controller.DeleteSwift(c)
@@ -407,3 +710,630 @@ func TestController_DeleteSwift(t *testing.T) {
 		})
 	}
 }
+
+func TestController_RestoreSwift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
+	mockSwiftService := mocks.NewMockSwiftService(ctrl)
+
+	controller := Controller{
+		SwiftRepo:    mockSwiftRepo,
+		SwiftService: mockSwiftService,
+	}
+
+	tests := []struct {
+		name           string
+		swiftCode      string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name:      "Success",
+			swiftCode: "ABCDEF12XXX",
+			mockSetup: func() {
+				mockSwiftService.EXPECT().RestoreSwift(gomock.Any(), "ABCDEF12XXX", mockSwiftRepo).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "Error - Swift is not deleted",
+			swiftCode: "ABCDEF12XXX",
+			mockSetup: func() {
+				mockSwiftService.EXPECT().RestoreSwift(gomock.Any(), "ABCDEF12XXX", mockSwiftRepo).Return(customErrors.ErrSwiftNotDeleted)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/swift/"+tt.swiftCode+"/restore", nil)
+			c.Params = gin.Params{gin.Param{Key: "swiftCode", Value: tt.swiftCode}}
+
+			controller.RestoreSwift(c)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestController_GetSwiftHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
+	mockSwiftService := mocks.NewMockSwiftService(ctrl)
+
+	controller := Controller{
+		SwiftRepo:    mockSwiftRepo,
+		SwiftService: mockSwiftService,
+	}
+
+	tests := []struct {
+		name           string
+		swiftCode      string
+		mockSetup      func()
+		expectedStatus int
+		expectedBody   gin.H
+	}{
+		{
+			name:      "Success",
+			swiftCode: "abcdef12XXX",
+			mockSetup: func() {
+				mockSwiftService.EXPECT().GetSwiftHistory(gomock.Any(), "ABCDEF12XXX", mockSwiftRepo).Return([]models.SwiftAudit{
+					{ID: 1, SwiftCode: "ABCDEF12XXX", Action: models.AuditActionAdd, Actor: "anonymous", RequestID: "req-1", After: json.RawMessage(`{"swiftCode":"ABCDEF12XXX"}`)},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: gin.H{
+				"swiftCode": "ABCDEF12XXX",
+				"history": []interface{}{
+					map[string]interface{}{
+						"id":        float64(1),
+						"swiftCode": "ABCDEF12XXX",
+						"action":    "add",
+						"actor":     "anonymous",
+						"requestId": "req-1",
+						"before":    nil,
+						"after":     map[string]interface{}{"swiftCode": "ABCDEF12XXX"},
+						"createdAt": "0001-01-01T00:00:00Z",
+					},
+				},
+			},
+		},
+		{
+			name:      "Error - Swift not found",
+			swiftCode: "INVALIDCODE",
+			mockSetup: func() {
+				mockSwiftService.EXPECT().GetSwiftHistory(gomock.Any(), "INVALIDCODE", mockSwiftRepo).Return(nil, customErrors.ErrSwiftNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problemBody(customErrors.ErrSwiftNotFound, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/swift/"+tt.swiftCode+"/history", nil)
+			c.Params = gin.Params{gin.Param{Key: "swiftCode", Value: tt.swiftCode}}
+
+			controller.GetSwiftHistory(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var responseBody gin.H
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
+
+func TestController_UpdateSwift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
+	mockSwiftService := mocks.NewMockSwiftService(ctrl)
+	mockValidator := mocks.NewMockSwiftValidator(ctrl)
+
+	controller := Controller{
+		SwiftRepo:    mockSwiftRepo,
+		SwiftService: mockSwiftService,
+		Validate:     mockValidator,
+	}
+
+	tests := []struct {
+		name           string
+		swiftCode      string
+		jsonBody       string
+		mockSetup      func()
+		expectedStatus int
+		expectedBody   gin.H
+	}{
+		{
+			name:      "Success",
+			swiftCode: "ABCDEF12XXX",
+			jsonBody: `{
+				"address": "123 Main St",
+				"bankName": "Bank of Test",
+				"countryIso2": "US",
+				"countryName": "United States",
+				"isHeadquarter": true,
+				"swiftCode": "ABCDEF12XXX"
+			}`,
+			mockSetup: func() {
+				mockSwiftService.EXPECT().UpdateSwift(gomock.Any(), "ABCDEF12XXX", gomock.Any(), mockSwiftRepo, mockValidator).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   gin.H{"message": "Swift code updated successfully"},
+		},
+		{
+			name:           "Error - Invalid JSON",
+			swiftCode:      "ABCDEF12XXX",
+			jsonBody:       `dsadasdasdasd`,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problemBody(customErrors.ErrBadRequest, ""),
+		},
+		{
+			name:      "Error - Swift not found",
+			swiftCode: "ABCDEF12XXX",
+			jsonBody:  `{"swiftCode": "ABCDEF12XXX"}`,
+			mockSetup: func() {
+				mockSwiftService.EXPECT().UpdateSwift(gomock.Any(), "ABCDEF12XXX", gomock.Any(), mockSwiftRepo, mockValidator).Return(customErrors.ErrSwiftNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   problemBody(customErrors.ErrSwiftNotFound, ""),
+		},
+		{
+			name:      "Error - Swift code mismatch",
+			swiftCode: "ABCDEF12XXX",
+			jsonBody:  `{"swiftCode": "ZZZZZZZZXXX"}`,
+			mockSetup: func() {
+				mockSwiftService.EXPECT().UpdateSwift(gomock.Any(), "ABCDEF12XXX", gomock.Any(), mockSwiftRepo, mockValidator).Return(customErrors.ErrSwiftCodeMismatch)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   problemBody(customErrors.ErrSwiftCodeMismatch, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/swift/"+tt.swiftCode, strings.NewReader(tt.jsonBody))
+			c.Params = gin.Params{gin.Param{Key: "swiftCode", Value: tt.swiftCode}}
+
+			controller.UpdateSwift(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				var responseBody gin.H
+				err := json.Unmarshal(w.Body.Bytes(), &responseBody)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBody, responseBody)
+			}
+		})
+	}
+}
+
+func TestController_PatchSwift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
+	mockSwiftService := mocks.NewMockSwiftService(ctrl)
+	mockValidator := mocks.NewMockSwiftValidator(ctrl)
+
+	controller := Controller{
+		SwiftRepo:    mockSwiftRepo,
+		SwiftService: mockSwiftService,
+		Validate:     mockValidator,
+	}
+
+	tests := []struct {
+		name           string
+		swiftCode      string
+		contentType    string
+		jsonBody       string
+		mockSetup      func()
+		expectedStatus int
+		expectedBody   gin.H
+	}{
+		{
+			name:        "Success",
+			swiftCode:   "ABCDEF12XXX",
+			contentType: "application/merge-patch+json",
+			jsonBody:    `{"address": "456 New St"}`,
+			mockSetup: func() {
+				mockSwiftService.EXPECT().PatchSwift(gomock.Any(), "ABCDEF12XXX", []byte(`{"address": "456 New St"}`), mockSwiftRepo, mockValidator).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   gin.H{"message": "Swift code updated successfully"},
+		},
+		{
+			name:        "Error - Wrong field type",
+			swiftCode:   "ABCDEF12XXX",
+			contentType: "application/merge-patch+json",
+			jsonBody:    `{"isHeadquarter": "dsadas"}`,
+			mockSetup: func() {
+				mockSwiftService.EXPECT().PatchSwift(gomock.Any(), "ABCDEF12XXX", gomock.Any(), mockSwiftRepo, mockValidator).Return(
+					json.Unmarshal([]byte(`{"isHeadquarter": "dsadas"}`), &models.Swift{}),
+				)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problemBody(customErrors.ErrInvalidFieldType, "isHeadquarter should be bool"),
+		},
+		{
+			name:           "Error - Not a merge patch",
+			swiftCode:      "ABCDEF12XXX",
+			contentType:    "application/json",
+			jsonBody:       `{"address": "456 New St"}`,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedBody:   problemBody(customErrors.ErrUnsupportedMediaType, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPatch, "/swift/"+tt.swiftCode, strings.NewReader(tt.jsonBody))
+			c.Request.Header.Set("Content-Type", tt.contentType)
+			c.Params = gin.Params{gin.Param{Key: "swiftCode", Value: tt.swiftCode}}
+
+			controller.PatchSwift(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				var responseBody gin.H
+				err := json.Unmarshal(w.Body.Bytes(), &responseBody)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBody, responseBody)
+			}
+		})
+	}
+}
+
+func TestController_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
+	mockSwiftService := mocks.NewMockSwiftService(ctrl)
+
+	controller := Controller{
+		SwiftRepo:    mockSwiftRepo,
+		SwiftService: mockSwiftService,
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func()
+		expectedStatus int
+		expectedBody   gin.H
+	}{
+		{
+			name:  "Success",
+			query: "?q=test&country=US&limit=1&offset=1",
+			mockSetup: func() {
+				mockSwiftService.EXPECT().Search(gomock.Any(), models.SearchQuery{
+					Query:   "test",
+					Country: "US",
+					Limit:   1,
+					Offset:  1,
+				}, mockSwiftRepo).Return(
+					[]models.SwiftMini{
+						{
+							SwiftCode:     "ABCDEF12XXX",
+							BankName:      "Bank of Test",
+							CountryIso2:   "US",
+							IsHeadquarter: true,
+							Address:       "123 Main St",
+						},
+					},
+					2,
+					nil,
+				)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: gin.H{
+				"nextOffset": float64(2),
+				"swiftCodes": []interface{}{
+					map[string]interface{}{
+						"swiftCode":     "ABCDEF12XXX",
+						"codeType":      "",
+						"townName":      "",
+						"timeZone":      "",
+						"bankName":      "Bank of Test",
+						"countryISO2":   "US",
+						"isHeadquarter": true,
+						"address":       "123 Main St",
+					},
+				},
+			},
+		},
+		{
+			name:  "Error - Missing query",
+			query: "",
+			mockSetup: func() {
+				mockSwiftService.EXPECT().Search(gomock.Any(), models.SearchQuery{}, mockSwiftRepo).Return(
+					nil, 0, customErrors.ErrSearchQueryRequired,
+				)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problemBody(customErrors.ErrSearchQueryRequired, ""),
+		},
+		{
+			name:           "Error - Invalid offset",
+			query:          "?q=test&offset=-1",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problemBody(customErrors.ErrInvalidPageOffset, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/swift/search"+tt.query, nil)
+
+			controller.Search(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				var responseBody gin.H
+				err := json.Unmarshal(w.Body.Bytes(), &responseBody)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBody, responseBody)
+			}
+		})
+	}
+}
+
+func TestController_AddSwifts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
+	mockSwiftService := mocks.NewMockSwiftService(ctrl)
+	mockValidator := mocks.NewMockSwiftValidator(ctrl)
+
+	controller := Controller{
+		SwiftRepo:    mockSwiftRepo,
+		SwiftService: mockSwiftService,
+		Validate:     mockValidator,
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		contentType    string
+		body           string
+		mockSetup      func()
+		expectedStatus int
+		expectedBody   gin.H
+	}{
+		{
+			name:        "Success - JSON array",
+			contentType: "application/json",
+			body:        `[{"swiftCode": "ABCDEF12XXX"}, {"swiftCode": "abcdef12001"}]`,
+			mockSetup: func() {
+				mockSwiftService.EXPECT().AddSwifts(gomock.Any(), []models.Swift{
+					{SwiftCode: "ABCDEF12XXX"},
+					{SwiftCode: "abcdef12001"},
+				}, true, mockSwiftRepo, mockValidator).Return([]error{nil, nil}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: gin.H{
+				"created": float64(2),
+				"failed":  float64(0),
+				"results": []interface{}{
+					map[string]interface{}{"index": float64(0), "swiftCode": "ABCDEF12XXX", "status": float64(201), "message": "Swift code added successfully"},
+					map[string]interface{}{"index": float64(1), "swiftCode": "ABCDEF12001", "status": float64(201), "message": "Swift code added successfully"},
+				},
+			},
+		},
+		{
+			name:        "Partial success - NDJSON best effort",
+			query:       "?mode=best-effort",
+			contentType: "application/x-ndjson",
+			body:        "{\"swiftCode\": \"ABCDEF12XXX\"}\n{\"swiftCode\": \"ABCDEF12001\", \"isHeadquarter\": \"no\"}\n{\"swiftCode\": \"ABCDEF12002\"}\n",
+			mockSetup: func() {
+				mockSwiftService.EXPECT().AddSwifts(gomock.Any(), []models.Swift{
+					{SwiftCode: "ABCDEF12XXX"},
+					{SwiftCode: "ABCDEF12002"},
+				}, false, mockSwiftRepo, mockValidator).
+					DoAndReturn(func(_ context.Context, swifts []models.Swift, _ bool, _ repositories.SwiftRepo, _ models.SwiftValidator) ([]error, error) {
+						swifts[1].HeadquarterMissing = true
+						return []error{customErrors.ErrSwiftCodeAlreadyExists, nil}, nil
+					})
+			},
+			expectedStatus: http.StatusMultiStatus,
+			expectedBody: gin.H{
+				"created": float64(1),
+				"failed":  float64(2),
+				"results": []interface{}{
+					map[string]interface{}{"index": float64(0), "swiftCode": "ABCDEF12XXX", "status": float64(409), "code": "SWIFT_ALREADY_EXISTS", "message": "Swift code already exists"},
+					map[string]interface{}{"index": float64(1), "swiftCode": "", "status": float64(400), "code": "INVALID_FIELD_TYPE", "message": "isHeadquarter should be bool"},
+					map[string]interface{}{"index": float64(2), "swiftCode": "ABCDEF12002", "status": float64(201), "message": "Swift code added successfully", "headquarterMissing": true},
+				},
+			},
+		},
+		{
+			name:        "Error - Atomic batch with undecodable item",
+			contentType: "application/json",
+			body:        `[{"swiftCode": "ABCDEF12XXX"}, {"isHeadquarter": "no"}, {"swiftCode": "ABCDEF12001"}]`,
+			mockSetup: func() {
+				mockSwiftService.EXPECT().CheckSwifts(gomock.Any(), []models.Swift{
+					{SwiftCode: "ABCDEF12XXX"},
+					{SwiftCode: "ABCDEF12001"},
+				}, mockSwiftRepo, mockValidator).Return([]error{nil, customErrors.ErrSwiftCodeAlreadyExists})
+			},
+			expectedStatus: http.StatusMultiStatus,
+			expectedBody: gin.H{
+				"created": float64(0),
+				"failed":  float64(3),
+				"results": []interface{}{
+					map[string]interface{}{"index": float64(0), "swiftCode": "ABCDEF12XXX", "status": float64(424), "code": "BULK_ABORTED", "message": "Not added because another item in the batch failed"},
+					map[string]interface{}{"index": float64(1), "swiftCode": "", "status": float64(400), "code": "INVALID_FIELD_TYPE", "message": "isHeadquarter should be bool"},
+					map[string]interface{}{"index": float64(2), "swiftCode": "ABCDEF12001", "status": float64(409), "code": "SWIFT_ALREADY_EXISTS", "message": "Swift code already exists"},
+				},
+			},
+		},
+		{
+			name:           "Error - Empty batch",
+			contentType:    "application/json",
+			body:           `[]`,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problemBody(customErrors.ErrBulkEmpty, ""),
+		},
+		{
+			name:           "Error - Invalid mode",
+			query:          "?mode=sometimes",
+			contentType:    "application/json",
+			body:           `[{"swiftCode": "ABCDEF12XXX"}]`,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problemBody(customErrors.ErrInvalidBulkMode, ""),
+		},
+		{
+			name:           "Error - Not a JSON array",
+			contentType:    "application/json",
+			body:           `{"swiftCode": "ABCDEF12XXX"}`,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   problemBody(customErrors.ErrBadRequest, ""),
+		},
+		{
+			name:           "Error - Body too large",
+			contentType:    "application/x-ndjson",
+			body:           strings.Repeat(`{"swiftCode": "ABCDEF12XXX", "address": "`+strings.Repeat("A", maxBulkItemBytes)+`"}`+"\n", maxBulkSize),
+			mockSetup:      func() {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   problemBody(customErrors.ErrBulkBodyTooLarge, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/swift/bulk"+tt.query, strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", tt.contentType)
+
+			controller.AddSwifts(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var responseBody gin.H
+			err := json.Unmarshal(w.Body.Bytes(), &responseBody)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
+
+func TestController_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
+	mockSwiftService := mocks.NewMockSwiftService(ctrl)
+
+	controller := Controller{
+		SwiftRepo:    mockSwiftRepo,
+		SwiftService: mockSwiftService,
+	}
+
+	exportSwifts := func(_ context.Context, _ string, _ repositories.SwiftRepo, write func(*models.Swift) error) error {
+		return write(&models.Swift{
+			CountryIso2:   "AL",
+			SwiftCode:     "AAISALTRXXX",
+			CodeType:      "BIC11",
+			BankName:      "UNITED BANK OF ALBANIA SH.A",
+			Address:       "HYRJA 3 RR. DRITAN HOXHA ND. 11 TIRANA, TIRANA, 1023",
+			TownName:      "TIRANA",
+			CountryName:   "ALBANIA",
+			TimeZone:      "Europe/Tirane",
+			IsHeadquarter: true,
+		})
+	}
+
+	tests := []struct {
+		name                string
+		query               string
+		mockSetup           func()
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:  "Success - CSV",
+			query: "?country=AL",
+			mockSetup: func() {
+				mockSwiftService.EXPECT().ExportSwifts(gomock.Any(), "AL", mockSwiftRepo, gomock.Any()).DoAndReturn(exportSwifts)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody: "COUNTRY ISO2 CODE,SWIFT CODE,CODE TYPE,NAME,ADDRESS,TOWN NAME,COUNTRY NAME,TIME ZONE\n" +
+				"AL,AAISALTRXXX,BIC11,UNITED BANK OF ALBANIA SH.A,\"HYRJA 3 RR. DRITAN HOXHA ND. 11 TIRANA, TIRANA, 1023\",TIRANA,ALBANIA,Europe/Tirane\n",
+		},
+		{
+			name:  "Success - NDJSON",
+			query: "?format=ndjson",
+			mockSetup: func() {
+				mockSwiftService.EXPECT().ExportSwifts(gomock.Any(), "", mockSwiftRepo, gomock.Any()).DoAndReturn(exportSwifts)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			expectedBody: `{"countryISO2":"AL","swiftCode":"AAISALTRXXX","codeType":"BIC11","bankName":"UNITED BANK OF ALBANIA SH.A",` +
+				`"address":"HYRJA 3 RR. DRITAN HOXHA ND. 11 TIRANA, TIRANA, 1023","townName":"TIRANA","countryName":"ALBANIA",` +
+				`"timeZone":"Europe/Tirane","isHeadquarter":true}` + "\n",
+		},
+		{
+			name:                "Error - Invalid format",
+			query:               "?format=xml",
+			mockSetup:           func() {},
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: customErrors.ProblemContentType,
+			expectedBody: `{"type":"urn:swift-codes:problem:invalid-export-format","title":"Bad Request","status":400,` +
+				`"detail":"format must be one of: csv, ndjson","code":"INVALID_EXPORT_FORMAT"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/swift/export"+tt.query, nil)
+
+			controller.Export(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -6,8 +6,9 @@ import (
 )
 
 type HttpError struct {
-	code    int
-	message string
+	code      int
+	errorCode string
+	message   string
 }
 
 func (e *HttpError) Error() string {
@@ -16,20 +17,50 @@ func (e *HttpError) Error() string {
 
 func (e *HttpError) Code() int { return e.code }
 
+// ErrorCode is the stable, machine-readable name of the error, such as
+// SWIFT_NOT_FOUND, that clients can rely on instead of the message.
+func (e *HttpError) ErrorCode() string { return e.errorCode }
+
 func (e *HttpError) Message() string { return e.message }
 
 func (e *HttpError) Send(c *gin.Context) {
-	c.JSON(e.Code(), gin.H{"message": e.Message()})
+	NewProblem(e).Send(c)
 }
 
-func NewHttpError(code int, message string) *HttpError {
+func NewHttpError(code int, errorCode string, message string) *HttpError {
 	return &HttpError{
-		code:    code,
-		message: message,
+		code:      code,
+		errorCode: errorCode,
+		message:   message,
 	}
 }
 
-var ErrSwiftNotFound = NewHttpError(http.StatusNotFound, "Swift not found")
-var ErrUnknown = NewHttpError(http.StatusInternalServerError, "Something went wrong")
-var ErrBadRequest = NewHttpError(http.StatusBadRequest, "Bad request")
-var ErrSwiftCodeAlreadyExists = NewHttpError(http.StatusConflict, "Swift code already exists")
+var ErrSwiftNotFound = NewHttpError(http.StatusNotFound, "SWIFT_NOT_FOUND", "Swift not found")
+var ErrUnknown = NewHttpError(http.StatusInternalServerError, "INTERNAL_ERROR", "Something went wrong")
+var ErrBadRequest = NewHttpError(http.StatusBadRequest, "BAD_REQUEST", "Bad request")
+var ErrSwiftCodeAlreadyExists = NewHttpError(http.StatusConflict, "SWIFT_ALREADY_EXISTS", "Swift code already exists")
+var ErrSwiftCodeMismatch = NewHttpError(http.StatusConflict, "SWIFT_CODE_MISMATCH", "Swift code in body does not match the URL")
+var ErrInvalidPageLimit = NewHttpError(http.StatusBadRequest, "INVALID_LIMIT", "limit must be a number between 1 and 1000")
+var ErrInvalidSortBy = NewHttpError(http.StatusBadRequest, "INVALID_SORT", "sort must be one of: swiftCode, bankName")
+var ErrInvalidHeadquarterMissing = NewHttpError(http.StatusBadRequest, "INVALID_HEADQUARTER_MISSING", "headquarterMissing must be true or false")
+var ErrInvalidCursor = NewHttpError(http.StatusBadRequest, "INVALID_CURSOR", "after must be a swift code of the country")
+var ErrInvalidPageOffset = NewHttpError(http.StatusBadRequest, "INVALID_OFFSET", "offset must be a non-negative number")
+var ErrSearchQueryRequired = NewHttpError(http.StatusBadRequest, "SEARCH_QUERY_REQUIRED", "q is required")
+var ErrBulkAborted = NewHttpError(http.StatusFailedDependency, "BULK_ABORTED", "Not added because another item in the batch failed")
+var ErrBulkEmpty = NewHttpError(http.StatusBadRequest, "BULK_EMPTY", "Batch must contain at least one swift code")
+var ErrBulkTooLarge = NewHttpError(http.StatusRequestEntityTooLarge, "BULK_TOO_LARGE", "Batch must not contain more than 1000 swift codes")
+var ErrBulkBodyTooLarge = NewHttpError(http.StatusRequestEntityTooLarge, "BULK_BODY_TOO_LARGE", "Batch must not be larger than 4 MiB")
+var ErrInvalidBulkMode = NewHttpError(http.StatusBadRequest, "INVALID_BULK_MODE", "mode must be one of: atomic, best-effort")
+var ErrInvalidExportFormat = NewHttpError(http.StatusBadRequest, "INVALID_EXPORT_FORMAT", "format must be one of: csv, ndjson")
+var ErrHeadquarterNotFound = NewHttpError(http.StatusUnprocessableEntity, "HEADQUARTER_NOT_FOUND", "Headquarter of the branch does not exist")
+var ErrHeadquarterHasBranches = NewHttpError(http.StatusConflict, "HEADQUARTER_HAS_BRANCHES", "Headquarter still has branches, delete them first")
+var ErrSwiftNotDeleted = NewHttpError(http.StatusConflict, "SWIFT_NOT_DELETED", "Swift is not deleted")
+var ErrUnauthorized = NewHttpError(http.StatusUnauthorized, "UNAUTHORIZED", "Missing or invalid credentials")
+var ErrForbidden = NewHttpError(http.StatusForbidden, "FORBIDDEN", "Credentials do not allow this operation")
+var ErrNotFound = NewHttpError(http.StatusNotFound, "NOT_FOUND", "No endpoint matches the path")
+var ErrMethodNotAllowed = NewHttpError(http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method is not allowed on this endpoint, see the Allow header")
+var ErrTooManyRequests = NewHttpError(http.StatusTooManyRequests, "RATE_LIMITED", "Too many requests, try again later")
+var ErrPreconditionFailed = NewHttpError(http.StatusPreconditionFailed, "PRECONDITION_FAILED", "Swift code has changed since it was read")
+var ErrValidationFailed = NewHttpError(http.StatusBadRequest, "VALIDATION_FAILED", "Request body failed validation")
+var ErrUnsupportedMediaType = NewHttpError(http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", "Content-Type must be application/merge-patch+json")
+var ErrInvalidFieldType = NewHttpError(http.StatusBadRequest, "INVALID_FIELD_TYPE", "Field has the wrong type")
@@ -0,0 +1,105 @@
+package customErrors
+
+import (
+	"awesomeProject/models"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"strings"
+)
+
+const ProblemContentType = "application/problem+json"
+
+const problemTypePrefix = "urn:swift-codes:problem:"
+
+// Problem is an RFC 7807 problem details body. Code is stable across
+// releases, while Title and Detail are meant for people and may change.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Code      string       `json:"code"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	RequestID string       `json:"requestId,omitempty"`
+}
+
+// FieldError describes one field of the request body that broke a
+// validation rule.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// NewProblem maps err to the problem sent to the client. Errors that are not
+// HttpErrors, type or validation errors become INTERNAL_ERROR, so their
+// messages never reach the client.
+func NewProblem(err error) *Problem {
+	var httpErr *HttpError
+	if errors.As(err, &httpErr) {
+		return problemOf(httpErr, httpErr.Message())
+	}
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) {
+		return problemOf(ErrInvalidFieldType, typeError.Field+" should be "+typeError.Type.Name())
+	}
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		problem := problemOf(ErrValidationFailed, ErrValidationFailed.Message())
+		for _, fieldErr := range validationErrs {
+			problem.Errors = append(problem.Errors, FieldError{
+				Field:   fieldErr.Field(),
+				Rule:    fieldErr.Tag(),
+				Message: fieldErrorMessage(fieldErr),
+			})
+		}
+		return problem
+	}
+	return problemOf(ErrUnknown, ErrUnknown.Message())
+}
+
+func problemOf(httpErr *HttpError, detail string) *Problem {
+	return &Problem{
+		Type:   problemTypePrefix + strings.ReplaceAll(strings.ToLower(httpErr.ErrorCode()), "_", "-"),
+		Title:  http.StatusText(httpErr.Code()),
+		Status: httpErr.Code(),
+		Detail: detail,
+		Code:   httpErr.ErrorCode(),
+	}
+}
+
+func fieldErrorMessage(fieldErr validator.FieldError) string {
+	field := fieldErr.Field()
+	switch fieldErr.Tag() {
+	case "required":
+		return field + " is required"
+	case "len":
+		return field + " must be " + fieldErr.Param() + " characters long"
+	case "alpha":
+		return field + " must contain only letters"
+	case "uppercase":
+		return field + " must be uppercase"
+	case "iso3166_1_alpha2":
+		return field + " must be an ISO 3166-1 alpha-2 country code"
+	case "oneof":
+		return field + " must be one of: " + fieldErr.Param()
+	case "timezone":
+		return field + " must be an IANA time zone"
+	case "boolean":
+		return field + " must be a boolean"
+	case models.HeadquarterConsistencyRule:
+		return field + " must be true exactly when swiftCode ends with XXX"
+	}
+	return field + " failed the " + fieldErr.Tag() + " rule"
+}
+
+// Send writes the problem as application/problem+json, tagged with the ID of
+// the request so clients can quote it when reporting the error.
+func (p *Problem) Send(c *gin.Context) {
+	p.RequestID = models.RequestIDFrom(c.Request.Context())
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(p.Status, p)
+}
@@ -0,0 +1,100 @@
+package customErrors
+
+import (
+	"awesomeProject/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNewProblem(t *testing.T) {
+	validationErr := models.NewSwiftValidator().Struct(models.Swift{
+		CountryIso2:   "PL",
+		SwiftCode:     "abcdefghxxx",
+		BankName:      "BANK",
+		Address:       "ADDRESS",
+		CountryName:   "POLAND",
+		IsHeadquarter: false,
+	})
+
+	tests := []struct {
+		name     string
+		err      error
+		expected *Problem
+	}{
+		{
+			name: "HttpError",
+			err:  fmt.Errorf("getting swift: %w", ErrSwiftNotFound),
+			expected: &Problem{
+				Type:   "urn:swift-codes:problem:swift-not-found",
+				Title:  "Not Found",
+				Status: http.StatusNotFound,
+				Detail: "Swift not found",
+				Code:   "SWIFT_NOT_FOUND",
+			},
+		},
+		{
+			name: "Type error",
+			err:  &json.UnmarshalTypeError{Field: "isHeadquarter", Type: reflect.TypeOf(true)},
+			expected: &Problem{
+				Type:   "urn:swift-codes:problem:invalid-field-type",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: "isHeadquarter should be bool",
+				Code:   "INVALID_FIELD_TYPE",
+			},
+		},
+		{
+			name: "Validation errors",
+			err:  validationErr,
+			expected: &Problem{
+				Type:   "urn:swift-codes:problem:validation-failed",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: "Request body failed validation",
+				Code:   "VALIDATION_FAILED",
+				Errors: []FieldError{
+					{Field: "swiftCode", Rule: "uppercase", Message: "swiftCode must be uppercase"},
+					{Field: "isHeadquarter", Rule: models.HeadquarterConsistencyRule, Message: "isHeadquarter must be true exactly when swiftCode ends with XXX"},
+				},
+			},
+		},
+		{
+			name: "Unknown error",
+			err:  errors.New("connection refused"),
+			expected: &Problem{
+				Type:   "urn:swift-codes:problem:internal-error",
+				Title:  "Internal Server Error",
+				Status: http.StatusInternalServerError,
+				Detail: "Something went wrong",
+				Code:   "INTERNAL_ERROR",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewProblem(tt.err))
+		})
+	}
+}
+
+func TestProblem_Send(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request = c.Request.WithContext(models.WithRequestID(c.Request.Context(), "request-1"))
+
+	ErrTooManyRequests.Send(c)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"type":"urn:swift-codes:problem:rate-limited","title":"Too Many Requests","status":429,`+
+		`"detail":"Too many requests, try again later","code":"RATE_LIMITED","requestId":"request-1"}`, w.Body.String())
+}
@@ -1,9 +1,12 @@
 package dbs
 
-import "github.com/uptrace/bun"
+import (
+	"context"
+	"github.com/uptrace/bun"
+)
 
 type BunDBWrapper struct {
-	DB *bun.DB
+	DB bun.IDB
 }
 
 func (b *BunDBWrapper) NewSelect() SelectQuery {
@@ -14,6 +17,10 @@ func (b *BunDBWrapper) NewInsert() InsertQuery {
 	return b.DB.NewInsert()
 }
 
+func (b *BunDBWrapper) NewUpdate() UpdateQuery {
+	return b.DB.NewUpdate()
+}
+
 func (b *BunDBWrapper) NewDelete() DeleteQuery {
 	return b.DB.NewDelete()
 }
@@ -21,3 +28,9 @@ func (b *BunDBWrapper) NewDelete() DeleteQuery {
 func (b *BunDBWrapper) NewRaw(query string, args ...interface{}) RawQuery {
 	return b.DB.NewRaw(query, args...)
 }
+
+func (b *BunDBWrapper) RunInTx(ctx context.Context, fn func(ctx context.Context, db SwiftDb) error) error {
+	return b.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return fn(ctx, &BunDBWrapper{DB: &tx})
+	})
+}
@@ -17,6 +17,13 @@ type InsertQuery interface {
 	Exec(ctx context.Context, dest ...interface{}) (sql.Result, error)
 }
 
+type UpdateQuery interface {
+	Model(model interface{}) *bun.UpdateQuery
+	Set(query string, args ...interface{}) *bun.UpdateQuery
+	Where(query string, args ...interface{}) *bun.UpdateQuery
+	Exec(ctx context.Context, dest ...interface{}) (sql.Result, error)
+}
+
 type DeleteQuery interface {
 	Model(model interface{}) *bun.DeleteQuery
 	Where(query string, args ...interface{}) *bun.DeleteQuery
@@ -30,6 +37,10 @@ type RawQuery interface {
 type SwiftDb interface {
 	NewSelect() SelectQuery
 	NewInsert() InsertQuery
+	NewUpdate() UpdateQuery
 	NewDelete() DeleteQuery
 	NewRaw(query string, args ...interface{}) RawQuery
+	// RunInTx runs fn in a transaction; fn gets a SwiftDb bound to it.
+	// Nested calls use savepoints.
+	RunInTx(ctx context.Context, fn func(ctx context.Context, db SwiftDb) error) error
 }
@@ -1,6 +1,7 @@
 package dbs
 
 import (
+	"awesomeProject/metrics"
 	"context"
 	"database/sql"
 	"fmt"
@@ -9,15 +10,20 @@ import (
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/pgdialect"
 	"github.com/uptrace/bun/driver/pgdriver"
+	"github.com/uptrace/bun/extra/bunotel"
 )
 
 type Config struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	Database string
-	SSLMode  string
+	Host            string        `yaml:"host"`
+	Port            string        `yaml:"port"`
+	User            string        `yaml:"user"`
+	Password        string        `yaml:"password"`
+	Database        string        `yaml:"database"`
+	SSLMode         string        `yaml:"sslMode"`
+	MaxOpenConns    int           `yaml:"maxOpenConns"`
+	MaxIdleConns    int           `yaml:"maxIdleConns"`
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime"`
+	ConnectTimeout  time.Duration `yaml:"connectTimeout"`
 }
 
 func Connect(cfg *Config) *bun.DB {
@@ -26,13 +32,16 @@ func Connect(cfg *Config) *bun.DB {
 
 	sqlDB := sql.OpenDB(pgdriver.NewConnector(pgdriver.WithDSN(dsn)))
 
-	sqlDB.SetMaxOpenConns(25)
-	sqlDB.SetMaxIdleConns(25)
-	sqlDB.SetConnMaxLifetime(5 * time.Minute)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
 	db := bun.NewDB(sqlDB, pgdialect.New())
+	db.AddQueryHook(metrics.QueryHook{})
+	db.AddQueryHook(bunotel.NewQueryHook(bunotel.WithDBName(cfg.Database)))
+	metrics.RegisterDB(sqlDB)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
 	defer cancel()
 
 	if err := db.PingContext(ctx); err != nil {
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"context"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+func init() {
+	Migrations.Add(migrate.Migration{
+		Name:    "0001",
+		Comment: "create_swifts",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db,
+				`CREATE TABLE IF NOT EXISTS swifts (
+					country_iso2_code VARCHAR NOT NULL,
+					swift_code VARCHAR NOT NULL,
+					bank_name VARCHAR NOT NULL,
+					address VARCHAR NOT NULL,
+					country_name VARCHAR NOT NULL,
+					is_headquarter BOOLEAN NOT NULL,
+					PRIMARY KEY (swift_code)
+				)`,
+				`CREATE INDEX IF NOT EXISTS idx_swift_code_prefix ON swifts (LEFT(swift_code, 8))`,
+				`CREATE INDEX IF NOT EXISTS idx_country_iso2_code ON swifts (country_iso2_code)`,
+			)
+		},
+		Down: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db, `DROP TABLE IF EXISTS swifts`)
+		},
+	})
+}
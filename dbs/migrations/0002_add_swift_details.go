@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"context"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+func init() {
+	Migrations.Add(migrate.Migration{
+		Name:    "0002",
+		Comment: "add_swift_details",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db,
+				`ALTER TABLE swifts ADD COLUMN IF NOT EXISTS code_type VARCHAR NOT NULL DEFAULT ''`,
+				`ALTER TABLE swifts ADD COLUMN IF NOT EXISTS town_name VARCHAR NOT NULL DEFAULT ''`,
+				`ALTER TABLE swifts ADD COLUMN IF NOT EXISTS time_zone VARCHAR NOT NULL DEFAULT ''`,
+			)
+		},
+		Down: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db,
+				`ALTER TABLE swifts DROP COLUMN IF EXISTS time_zone`,
+				`ALTER TABLE swifts DROP COLUMN IF EXISTS town_name`,
+				`ALTER TABLE swifts DROP COLUMN IF EXISTS code_type`,
+			)
+		},
+	})
+}
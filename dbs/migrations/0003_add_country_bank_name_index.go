@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"context"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+func init() {
+	Migrations.Add(migrate.Migration{
+		Name:    "0003",
+		Comment: "add_country_bank_name_index",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db,
+				`CREATE INDEX IF NOT EXISTS idx_country_iso2_code_bank_name ON swifts (country_iso2_code, bank_name, swift_code)`,
+			)
+		},
+		Down: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db, `DROP INDEX IF EXISTS idx_country_iso2_code_bank_name`)
+		},
+	})
+}
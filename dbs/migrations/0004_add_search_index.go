@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"context"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+func init() {
+	Migrations.Add(migrate.Migration{
+		Name:    "0004",
+		Comment: "add_search_index",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db,
+				`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+				`CREATE INDEX IF NOT EXISTS idx_swift_search_trgm ON swifts USING GIN ((bank_name || ' ' || address || ' ' || town_name) gin_trgm_ops)`,
+			)
+		},
+		// The pg_trgm extension is left installed as other schemas may use it.
+		Down: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db, `DROP INDEX IF EXISTS idx_swift_search_trgm`)
+		},
+	})
+}
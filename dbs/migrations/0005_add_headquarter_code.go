@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"context"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+func init() {
+	Migrations.Add(migrate.Migration{
+		Name:    "0005",
+		Comment: "add_headquarter_code",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db,
+				`ALTER TABLE swifts ADD COLUMN IF NOT EXISTS headquarter_code VARCHAR
+					CONSTRAINT fk_swifts_headquarter_code REFERENCES swifts (swift_code)`,
+				`CREATE INDEX IF NOT EXISTS idx_headquarter_code ON swifts (headquarter_code)`,
+				linkAllBranchesQuery,
+			)
+		},
+		Down: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db, `ALTER TABLE swifts DROP COLUMN IF EXISTS headquarter_code`)
+		},
+	})
+}
+
+// linkAllBranchesQuery points every branch at the headquarter sharing its bank prefix.
+const linkAllBranchesQuery = `
+	UPDATE swifts AS b
+	SET headquarter_code = h.swift_code
+	FROM swifts AS h
+	WHERE h.swift_code = LEFT(b.swift_code, 8) || 'XXX'
+		AND RIGHT(b.swift_code, 3) != 'XXX'
+		AND b.headquarter_code IS DISTINCT FROM h.swift_code`
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"context"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+func init() {
+	Migrations.Add(migrate.Migration{
+		Name:    "0006",
+		Comment: "create_swift_audit",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db,
+				`CREATE TABLE IF NOT EXISTS swift_audit (
+					id BIGSERIAL PRIMARY KEY,
+					swift_code VARCHAR NOT NULL,
+					action VARCHAR NOT NULL,
+					actor VARCHAR NOT NULL,
+					request_id VARCHAR NOT NULL DEFAULT '',
+					before JSONB,
+					after JSONB,
+					created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+				)`,
+				`CREATE INDEX IF NOT EXISTS idx_swift_audit_swift_code ON swift_audit (swift_code, id)`,
+				// The audit log is append-only, even for clients with direct database access.
+				`CREATE OR REPLACE FUNCTION swift_audit_append_only() RETURNS trigger AS $$
+				BEGIN
+					RAISE EXCEPTION 'swift_audit is append-only';
+				END;
+				$$ LANGUAGE plpgsql`,
+				`DROP TRIGGER IF EXISTS swift_audit_append_only ON swift_audit`,
+				`CREATE TRIGGER swift_audit_append_only BEFORE UPDATE OR DELETE ON swift_audit
+					FOR EACH ROW EXECUTE FUNCTION swift_audit_append_only()`,
+			)
+		},
+		Down: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db,
+				`DROP TABLE IF EXISTS swift_audit`,
+				`DROP FUNCTION IF EXISTS swift_audit_append_only()`,
+			)
+		},
+	})
+}
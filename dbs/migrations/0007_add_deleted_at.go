@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"context"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+func init() {
+	Migrations.Add(migrate.Migration{
+		Name:    "0007",
+		Comment: "add_deleted_at",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db,
+				`ALTER TABLE swifts ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ`,
+				`CREATE INDEX IF NOT EXISTS idx_deleted_at ON swifts (deleted_at) WHERE deleted_at IS NOT NULL`,
+			)
+		},
+		// Down purges the deleted swift codes, otherwise dropping the column
+		// would bring them back.
+		Down: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db,
+				`UPDATE swifts SET headquarter_code = NULL
+					WHERE headquarter_code IN (SELECT swift_code FROM swifts WHERE deleted_at IS NOT NULL)`,
+				`DELETE FROM swifts WHERE deleted_at IS NOT NULL`,
+				`ALTER TABLE swifts DROP COLUMN IF EXISTS deleted_at`,
+			)
+		},
+	})
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"context"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+func init() {
+	Migrations.Add(migrate.Migration{
+		Name:    "0008",
+		Comment: "create_api_keys",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db,
+				`CREATE TABLE IF NOT EXISTS api_keys (
+					id BIGSERIAL PRIMARY KEY,
+					name VARCHAR NOT NULL UNIQUE,
+					key_hash VARCHAR NOT NULL UNIQUE,
+					scope VARCHAR NOT NULL CHECK (scope IN ('read', 'write')),
+					created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+					revoked_at TIMESTAMPTZ
+				)`,
+			)
+		},
+		Down: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db, `DROP TABLE IF EXISTS api_keys`)
+		},
+	})
+}
@@ -0,0 +1,74 @@
+package migrations
+
+import (
+	"context"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+func init() {
+	Migrations.Add(migrate.Migration{
+		Name:    "0009",
+		Comment: "add_country_versions",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db,
+				`ALTER TABLE swifts ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT current_timestamp`,
+				`CREATE TABLE IF NOT EXISTS country_versions (
+					country_iso2_code VARCHAR PRIMARY KEY,
+					version BIGINT NOT NULL,
+					updated_at TIMESTAMPTZ NOT NULL
+				)`,
+				`INSERT INTO country_versions (country_iso2_code, version, updated_at)
+					SELECT country, 1, current_timestamp FROM (
+						SELECT country_iso2_code AS country FROM swifts
+						UNION SELECT UPPER(SUBSTRING(swift_code FROM 5 FOR 2)) FROM swifts
+					) AS countries
+					ON CONFLICT DO NOTHING`,
+				// Every write stamps the row, whoever makes it: the API, the importer or the purge.
+				`CREATE OR REPLACE FUNCTION swifts_touch() RETURNS trigger AS $$
+				BEGIN
+					NEW.updated_at := clock_timestamp();
+					RETURN NEW;
+				END;
+				$$ LANGUAGE plpgsql`,
+				`DROP TRIGGER IF EXISTS swifts_touch ON swifts`,
+				`CREATE TRIGGER swifts_touch BEFORE INSERT OR UPDATE ON swifts
+					FOR EACH ROW EXECUTE FUNCTION swifts_touch()`,
+				// The version row stays locked until the writing transaction ends, so
+				// versions increase in commit order. Countries are bumped in a fixed
+				// order to avoid deadlocks between writers.
+				`CREATE OR REPLACE FUNCTION swifts_bump_country_version() RETURNS trigger AS $$
+				DECLARE
+					countries TEXT[] := '{}';
+				BEGIN
+					IF TG_OP <> 'DELETE' THEN
+						countries := countries || ARRAY[NEW.country_iso2_code, UPPER(SUBSTRING(NEW.swift_code FROM 5 FOR 2))];
+					END IF;
+					IF TG_OP <> 'INSERT' THEN
+						countries := countries || ARRAY[OLD.country_iso2_code, UPPER(SUBSTRING(OLD.swift_code FROM 5 FOR 2))];
+					END IF;
+
+					INSERT INTO country_versions (country_iso2_code, version, updated_at)
+						SELECT DISTINCT country, 1, clock_timestamp() FROM unnest(countries) AS country ORDER BY country
+						ON CONFLICT (country_iso2_code) DO UPDATE
+							SET version = country_versions.version + 1, updated_at = EXCLUDED.updated_at;
+					RETURN NULL;
+				END;
+				$$ LANGUAGE plpgsql`,
+				`DROP TRIGGER IF EXISTS swifts_bump_country_version ON swifts`,
+				`CREATE TRIGGER swifts_bump_country_version AFTER INSERT OR UPDATE OR DELETE ON swifts
+					FOR EACH ROW EXECUTE FUNCTION swifts_bump_country_version()`,
+			)
+		},
+		Down: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db,
+				`DROP TRIGGER IF EXISTS swifts_bump_country_version ON swifts`,
+				`DROP FUNCTION IF EXISTS swifts_bump_country_version()`,
+				`DROP TRIGGER IF EXISTS swifts_touch ON swifts`,
+				`DROP FUNCTION IF EXISTS swifts_touch()`,
+				`DROP TABLE IF EXISTS country_versions`,
+				`ALTER TABLE swifts DROP COLUMN IF EXISTS updated_at`,
+			)
+		},
+	})
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"context"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+func init() {
+	Migrations.Add(migrate.Migration{
+		Name:    "0010",
+		Comment: "add_headquarter_missing",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db,
+				`ALTER TABLE swifts ADD COLUMN IF NOT EXISTS headquarter_missing BOOLEAN NOT NULL DEFAULT false`,
+				// A branch is flagged whenever it has no headquarter, whoever writes it.
+				`CREATE OR REPLACE FUNCTION swifts_flag_headquarter_missing() RETURNS trigger AS $$
+				BEGIN
+					NEW.headquarter_missing := NEW.headquarter_code IS NULL AND RIGHT(NEW.swift_code, 3) != 'XXX';
+					RETURN NEW;
+				END;
+				$$ LANGUAGE plpgsql`,
+				`DROP TRIGGER IF EXISTS swifts_flag_headquarter_missing ON swifts`,
+				`CREATE TRIGGER swifts_flag_headquarter_missing BEFORE INSERT OR UPDATE ON swifts
+					FOR EACH ROW EXECUTE FUNCTION swifts_flag_headquarter_missing()`,
+				`UPDATE swifts SET headquarter_missing = true
+					WHERE headquarter_code IS NULL AND RIGHT(swift_code, 3) != 'XXX'`,
+				`CREATE INDEX IF NOT EXISTS idx_swifts_headquarter_missing ON swifts (country_iso2_code, swift_code)
+					WHERE headquarter_missing AND deleted_at IS NULL`,
+			)
+		},
+		Down: func(ctx context.Context, db *bun.DB) error {
+			return execInTx(ctx, db,
+				`DROP TRIGGER IF EXISTS swifts_flag_headquarter_missing ON swifts`,
+				`DROP FUNCTION IF EXISTS swifts_flag_headquarter_missing()`,
+				`ALTER TABLE swifts DROP COLUMN IF EXISTS headquarter_missing`,
+			)
+		},
+	})
+}
@@ -1,40 +1,137 @@
 package migrations
 
 import (
-	"awesomeProject/models"
 	"context"
 	"fmt"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+	"log/slog"
 )
 
+// Migrations holds every schema change in the order it is applied. Each file in this
+// package registers one migration from its init function; never edit an applied one,
+// add a new one instead.
+var Migrations = migrate.NewMigrations()
+
+// advisoryLockID is the pg_advisory_lock key held while migrating, so replicas starting
+// at the same time apply migrations one after another.
+const advisoryLockID = 4_718_203_551
+
+func newMigrator(db *bun.DB) *migrate.Migrator {
+	return migrate.NewMigrator(db, Migrations, migrate.WithMarkAppliedOnSuccess(true))
+}
+
+// execInTx runs the statements in one transaction.
+func execInTx(ctx context.Context, db *bun.DB, statements ...string) error {
+	return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for _, statement := range statements {
+			if _, err := tx.ExecContext(ctx, statement); err != nil {
+				return fmt.Errorf("failed to execute %q: %w", statement, err)
+			}
+		}
+		return nil
+	})
+}
+
+func withAdvisoryLock(ctx context.Context, db *bun.DB, fn func() error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func(conn bun.Conn) {
+		_ = conn.Close()
+	}(conn)
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock(?)", advisoryLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock(?)", advisoryLockID)
+	}()
+
+	return fn()
+}
+
+// Migrate applies every pending migration.
 func Migrate(db *bun.DB) error {
-	return db.RunInTx(context.Background(), nil, func(ctx context.Context, tx bun.Tx) error {
-		if _, err := tx.NewCreateTable().
-			IfNotExists().
-			Model((*models.Swift)(nil)).
-			Exec(ctx); err != nil {
-			return fmt.Errorf("failed to create table: %w", err)
+	ctx := context.Background()
+	migrator := newMigrator(db)
+
+	return withAdvisoryLock(ctx, db, func() error {
+		if err := migrator.Init(ctx); err != nil {
+			return fmt.Errorf("failed to create migrations table: %w", err)
 		}
 
-		if _, err := tx.NewCreateIndex().
-			IfNotExists().
-			Model((*models.Swift)(nil)).
-			Index("idx_swift_code_prefix").
-			ColumnExpr("LEFT(swift_code, 8)").
-			Exec(ctx); err != nil {
-			return fmt.Errorf("failed to create index on swift_code prefix: %w", err)
+		group, err := migrator.Migrate(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to migrate: %w", err)
 		}
 
-		if _, err := tx.NewCreateIndex().
-			IfNotExists().
-			Model((*models.Swift)(nil)).
-			Index("idx_country_iso2_code").
-			Column("country_iso2_code").
-			Exec(ctx); err != nil {
-			return fmt.Errorf("failed to create index on country_iso2_code: %w", err)
+		if group.IsZero() {
+			slog.InfoContext(ctx, "no pending migrations")
+			return nil
+		}
+		slog.InfoContext(ctx, "migrated", "group", group.String())
+		return nil
+	})
+}
+
+// Rollback reverts the most recently applied group of migrations.
+func Rollback(db *bun.DB) (*migrate.MigrationGroup, error) {
+	ctx := context.Background()
+	migrator := newMigrator(db)
+
+	var group *migrate.MigrationGroup
+	err := withAdvisoryLock(ctx, db, func() error {
+		if err := migrator.Init(ctx); err != nil {
+			return fmt.Errorf("failed to create migrations table: %w", err)
 		}
 
-		fmt.Println("Migrations done")
+		var err error
+		group, err = migrator.Rollback(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to roll back: %w", err)
+		}
 		return nil
 	})
+	return group, err
+}
+
+// Reset reverts every applied migration, leaving an empty schema.
+func Reset(db *bun.DB) error {
+	for {
+		group, err := Rollback(db)
+		if err != nil {
+			return err
+		}
+		if group.IsZero() {
+			return nil
+		}
+	}
+}
+
+// Status lists every known migration with the group it was applied in, if any.
+func Status(db *bun.DB) (migrate.MigrationSlice, error) {
+	ctx := context.Background()
+	migrator := newMigrator(db)
+
+	if err := migrator.Init(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create migrations table: %w", err)
+	}
+	return migrator.MigrationsWithStatus(ctx)
+}
+
+// Pending returns the names of the known migrations not applied yet. Unlike
+// Status it doesn't create the migrations table, so it only reads.
+func Pending(ctx context.Context, db *bun.DB) ([]string, error) {
+	ms, err := newMigrator(db).MigrationsWithStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []string
+	for _, migration := range ms.Unapplied() {
+		pending = append(pending, migration.Name)
+	}
+	return pending, nil
 }
@@ -0,0 +1,30 @@
+package health
+
+import (
+	"awesomeProject/dbs/migrations"
+	"context"
+	"fmt"
+	"github.com/uptrace/bun"
+	"strings"
+)
+
+// Database pings the database.
+func Database(db *bun.DB) Check {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// Migrations fails while any known migration is not applied.
+func Migrations(db *bun.DB) Check {
+	return func(ctx context.Context) error {
+		pending, err := migrations.Pending(ctx, db)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("pending migrations: %s", strings.Join(pending, ", "))
+		}
+		return nil
+	}
+}
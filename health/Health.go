@@ -0,0 +1,95 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+// Check returns why a dependency is not ready, or nil when it is.
+type Check func(ctx context.Context) error
+
+type CheckResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Run runs every check concurrently and reports each result. The report is
+// only StatusOK when every check passed.
+func Run(ctx context.Context, checks map[string]Check) Report {
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := CheckResult{Status: StatusOK}
+			if err := check(ctx); err != nil {
+				result = CheckResult{Status: StatusUnavailable, Error: err.Error()}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result.Status != StatusOK {
+				report.Status = StatusUnavailable
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
+
+var ErrNotFinished = errors.New("not finished")
+
+// Task tracks work that must finish before the service is ready, such as the
+// import on startup.
+type Task struct {
+	// result is nil while the task runs, then points at its error.
+	result atomic.Pointer[error]
+}
+
+func (task *Task) Finish() {
+	task.Fail(nil)
+}
+
+// Fail finishes the task with err, keeping the service unready for good when
+// err is not nil.
+func (task *Task) Fail(err error) {
+	task.result.Store(&err)
+}
+
+// Run runs fn and finishes the task with its error, which it returns.
+func (task *Task) Run(fn func() error) error {
+	err := fn()
+	task.Fail(err)
+	return err
+}
+
+// Check fails with ErrNotFinished until the task finishes, and with the
+// error of the task afterwards.
+func (task *Task) Check(context.Context) error {
+	result := task.result.Load()
+	if result == nil {
+		return ErrNotFinished
+	}
+	if *result != nil {
+		return fmt.Errorf("failed: %w", *result)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	var task Task
+	checks := map[string]Check{
+		"database": func(context.Context) error { return nil },
+		"import":   task.Check,
+	}
+
+	report := Run(context.Background(), checks)
+	assert.Equal(t, Report{
+		Status: StatusUnavailable,
+		Checks: map[string]CheckResult{
+			"database": {Status: StatusOK},
+			"import":   {Status: StatusUnavailable, Error: "not finished"},
+		},
+	}, report)
+
+	task.Finish()
+	report = Run(context.Background(), checks)
+	assert.Equal(t, StatusOK, report.Status)
+	assert.Equal(t, CheckResult{Status: StatusOK}, report.Checks["import"])
+
+	checks["migrations"] = func(context.Context) error { return errors.New("pending migrations: 0009_add_country_versions") }
+	report = Run(context.Background(), checks)
+	assert.Equal(t, StatusUnavailable, report.Status)
+	assert.Equal(t, "pending migrations: 0009_add_country_versions", report.Checks["migrations"].Error)
+}
+
+func TestRun_NoChecks(t *testing.T) {
+	assert.Equal(t, Report{Status: StatusOK, Checks: map[string]CheckResult{}}, Run(context.Background(), nil))
+}
+
+func TestTask(t *testing.T) {
+	importErr := errors.New("opening data.csv: no such file or directory")
+
+	tests := []struct {
+		name     string
+		fn       func() error
+		expected string
+	}{
+		{
+			name: "Finished",
+			fn:   func() error { return nil },
+		},
+		{
+			name:     "Failed",
+			fn:       func() error { return importErr },
+			expected: "failed: opening data.csv: no such file or directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var task Task
+			assert.ErrorIs(t, task.Check(context.Background()), ErrNotFinished)
+
+			err := task.Run(tt.fn)
+			assert.Equal(t, tt.fn(), err)
+
+			err = task.Check(context.Background())
+			if tt.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expected)
+			assert.ErrorIs(t, err, importErr)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"awesomeProject/configs"
+	"awesomeProject/dbs"
+	"awesomeProject/models"
+	"awesomeProject/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/uptrace/bun"
+	"os"
+)
+
+const usage = `Usage: go run ./internal/apikey [-config <file>] <command>
+
+Commands:
+  create -name <name> -scope read|write   create a key and print it once
+  list                                    list keys and whether they are revoked
+  revoke -name <name>                     revoke a key`
+
+func main() {
+	configPath := flag.String("config", "", "path to a YAML config file (defaults to $"+configs.ConfigFileEnv+")")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	config, err := configs.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if config.Repository != configs.RepositoryPostgres {
+		fmt.Fprintln(os.Stderr, "API keys are only stored in the postgres repository")
+		os.Exit(1)
+	}
+
+	db := dbs.Connect(
+		&config.DBConfig,
+	)
+
+	defer func(db *bun.DB) {
+		err := db.Close()
+		if err != nil {
+			fmt.Println("Error closing db")
+		}
+	}(db)
+
+	apiKeyRepo := repositories.ApiKeyRepoPostgres{
+		Db: &dbs.BunDBWrapper{DB: db},
+	}
+
+	err = run(context.Background(), apiKeyRepo, flag.Arg(0), flag.Args()[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, apiKeyRepo repositories.ApiKeyRepo, command string, args []string) error {
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	name := flags.String("name", "", "name of the key")
+	scope := flags.String("scope", models.ScopeRead, "scope of the key: read or write")
+
+	switch command {
+	case "create":
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if *name == "" {
+			return errors.New("create needs -name")
+		}
+		if !models.IsValidScope(*scope) {
+			return fmt.Errorf("invalid scope %q, must be %s or %s", *scope, models.ScopeRead, models.ScopeWrite)
+		}
+
+		key, err := models.GenerateApiKey()
+		if err != nil {
+			return err
+		}
+		err = apiKeyRepo.AddApiKey(ctx, &models.ApiKey{
+			Name:    *name,
+			KeyHash: models.HashApiKey(key),
+			Scope:   *scope,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Created %s key %q. Store it now, it cannot be shown again:\n%s\n", *scope, *name, key)
+		return nil
+	case "list":
+		apiKeys, err := apiKeyRepo.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+		for _, apiKey := range apiKeys {
+			state := "active"
+			if !apiKey.RevokedAt.IsZero() {
+				state = "revoked at " + apiKey.RevokedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%s\t%s\tcreated at %s\t%s\n", apiKey.Name, apiKey.Scope, apiKey.CreatedAt.Format("2006-01-02 15:04:05"), state)
+		}
+		return nil
+	case "revoke":
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if *name == "" {
+			return errors.New("revoke needs -name")
+		}
+
+		err := apiKeyRepo.RevokeApiKey(ctx, *name)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("no active key named %q", *name)
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Revoked key %q\n", *name)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q\n%s", command, usage)
+	}
+}
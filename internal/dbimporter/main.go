@@ -4,12 +4,29 @@ import (
 	"awesomeProject/configs"
 	"awesomeProject/dbs"
 	"awesomeProject/internal/dbimporter/utils"
+	"awesomeProject/logging"
+	"context"
+	"flag"
 	"fmt"
 	"github.com/uptrace/bun"
+	"os"
 )
 
 func main() {
-	config := configs.GetConfig()
+	configPath := flag.String("config", "", "path to a YAML config file (defaults to $"+configs.ConfigFileEnv+")")
+	prune := flag.Bool("prune", false, "delete swift codes that are not present in the file (defaults to import.prune)")
+	flag.Parse()
+
+	config, err := configs.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if config.Repository != configs.RepositoryPostgres {
+		fmt.Fprintln(os.Stderr, "the importer only supports the postgres repository")
+		os.Exit(1)
+	}
+
 	db := dbs.Connect(
 		&config.DBConfig,
 	)
@@ -20,8 +37,11 @@ func main() {
 			fmt.Println("Error closing db")
 		}
 	}(db)
-	csvFilePath := utils.GetFilePath()
-	err := utils.ImportData(csvFilePath, db)
+	csvFilePath := utils.GetFilePath(config.Import.Path)
+	_, err = utils.ImportData(context.Background(), csvFilePath, db, utils.ImportOptions{
+		Prune:  *prune || config.Import.Prune,
+		Logger: logging.New(os.Stdout, config.Log.SlogLevel()),
+	})
 	if err != nil {
 		panic(err)
 	}
@@ -1,26 +1,39 @@
 package utils
 
 import (
+	"awesomeProject/logging"
+	"awesomeProject/metrics"
 	"awesomeProject/models"
+	"awesomeProject/repositories"
 	"context"
+	"database/sql"
 	"encoding/csv"
+	"errors"
+	"flag"
 	"fmt"
 	"github.com/uptrace/bun"
 	"log"
+	"log/slog"
 	"os"
 	"strings"
 )
 
-func GetFilePath() string {
-	if len(os.Args) < 2 {
-		log.Fatal("Usage: go run import_csv.go <path_to_csv>")
+// GetFilePath returns the CSV path given on the command line, or defaultPath when there is none.
+func GetFilePath(defaultPath string) string {
+	if flag.NArg() < 1 {
+		if defaultPath != "" {
+			return defaultPath
+		}
+		log.Fatal("Usage: go run import_csv.go [-config <file>] [-prune] [path_to_csv]")
 		return ""
 	}
 
-	return os.Args[1]
+	return flag.Arg(0)
 }
 
-func parseCSVFile(csvFilePath string) ([]models.Swift, error) {
+// parseCSVFile reads the swift codes of a CSV file, skipping the rows that
+// can't be one.
+func parseCSVFile(csvFilePath string, logger *slog.Logger) ([]models.Swift, error) {
 	file, err := os.Open(csvFilePath)
 	if err != nil {
 		return nil, fmt.Errorf("could not open file %s: %v", csvFilePath, err)
@@ -29,7 +42,7 @@ func parseCSVFile(csvFilePath string) ([]models.Swift, error) {
 	defer func(file *os.File) {
 		err := file.Close()
 		if err != nil {
-			fmt.Println(err)
+			logger.Warn("closing import file", "file", csvFilePath, "error", err)
 		}
 	}(file)
 
@@ -42,50 +55,293 @@ func parseCSVFile(csvFilePath string) ([]models.Swift, error) {
 	}
 
 	var swifts []models.Swift
+	indexByCode := make(map[string]int)
 
 	for record, err := reader.Read(); err == nil; record, err = reader.Read() {
-		if len(record) < 8 {
+		line, _ := reader.FieldPos(0)
+		if len(record) < len(models.CSVHeader) {
+			logger.Warn("skipping import row with missing columns", "file", csvFilePath, "line", line)
 			continue
 		}
 
 		swift := models.Swift{
 			CountryIso2: strings.ToUpper(record[0]),
 			SwiftCode:   strings.ToUpper(record[1]),
+			CodeType:    strings.ToUpper(strings.TrimSpace(record[2])),
 			BankName:    record[3],
 			Address:     record[4],
+			TownName:    strings.TrimSpace(record[5]),
 			CountryName: strings.ToUpper(record[6]),
+			TimeZone:    strings.TrimSpace(record[7]),
 		}
 
 		if len(swift.SwiftCode) != 11 {
+			logger.Warn("skipping import row with an invalid swift code", "file", csvFilePath, "line", line, "swiftCode", swift.SwiftCode)
 			continue
 		}
 
 		swift.IsHeadquarter = models.IsSwiftCodeOfHeadquarter(swift.SwiftCode)
 
+		// A code listed twice would make the upsert touch the same row twice,
+		// so the last occurrence in the file wins.
+		if i, ok := indexByCode[swift.SwiftCode]; ok {
+			swifts[i] = swift
+			continue
+		}
+		indexByCode[swift.SwiftCode] = len(swifts)
 		swifts = append(swifts, swift)
 	}
 
 	return swifts, nil
 }
 
-func ImportData(csvFilePath string, db *bun.DB) error {
-	ctx := context.Background()
+type ImportOptions struct {
+	// Prune deletes swift codes that are no longer present in the file.
+	Prune bool
+	// Logger is slog.Default() when nil.
+	Logger *slog.Logger
+}
+
+type ImportResult struct {
+	Inserted  int
+	Updated   int
+	Unchanged int
+	Deleted   int
+}
+
+// record adds the rows of an import to metrics.ImportedRows.
+func (r *ImportResult) record() {
+	metrics.ImportedRows.WithLabelValues("inserted").Add(float64(r.Inserted))
+	metrics.ImportedRows.WithLabelValues("updated").Add(float64(r.Updated))
+	metrics.ImportedRows.WithLabelValues("unchanged").Add(float64(r.Unchanged))
+	metrics.ImportedRows.WithLabelValues("deleted").Add(float64(r.Deleted))
+}
+
+func (r *ImportResult) log(logger *slog.Logger, csvFilePath string) {
+	logger.Info("import finished", "file", csvFilePath,
+		"inserted", r.Inserted, "updated", r.Updated, "unchanged", r.Unchanged, "deleted", r.Deleted)
+}
+
+func (r *ImportResult) String() string {
+	return fmt.Sprintf("%d inserted, %d updated, %d unchanged, %d deleted",
+		r.Inserted, r.Updated, r.Unchanged, r.Deleted)
+}
+
+var upsertColumns = []string{
+	"country_iso2_code",
+	"code_type",
+	"bank_name",
+	"address",
+	"town_name",
+	"country_name",
+	"time_zone",
+	"is_headquarter",
+}
+
+// linkBranchesQuery points every branch at the headquarter sharing its bank
+// prefix, which also links branches imported before their headquarter.
+const linkBranchesQuery = `
+	UPDATE swifts AS b
+	SET headquarter_code = h.swift_code
+	FROM swifts AS h
+	WHERE h.swift_code = LEFT(b.swift_code, 8) || 'XXX'
+		AND RIGHT(b.swift_code, 3) != 'XXX'
+		AND b.headquarter_code IS DISTINCT FROM h.swift_code
+		AND b.deleted_at IS NULL
+		AND h.deleted_at IS NULL`
 
-	banks, err := parseCSVFile(csvFilePath)
+// pruneSwifts soft deletes the swift codes that are not in banks. Branches of
+// a deleted headquarter that are still in the file are kept without one.
+func pruneSwifts(ctx context.Context, tx bun.Tx, banks []models.Swift) (int, error) {
+	codes := make([]string, len(banks))
+	for i := range banks {
+		codes[i] = banks[i].SwiftCode
+	}
+
+	unlink := tx.NewUpdate().Model((*models.Swift)(nil)).Set("headquarter_code = NULL")
+	remove := tx.NewDelete().Model((*models.Swift)(nil))
+	if len(codes) > 0 {
+		unlink = unlink.Where("headquarter_code NOT IN (?)", bun.In(codes))
+		remove = remove.Where("swift_code NOT IN (?)", bun.In(codes))
+	} else {
+		unlink = unlink.Where("headquarter_code IS NOT NULL")
+		remove = remove.Where("TRUE")
+	}
 
+	if _, err := unlink.Exec(ctx); err != nil {
+		return 0, err
+	}
+	res, err := remove.Exec(ctx)
 	if err != nil {
-		return err
+		return 0, err
 	}
+	deleted, err := res.RowsAffected()
+	return int(deleted), err
+}
+
+// ImportData imports a CSV file in one transaction, which is rolled back if
+// ctx is cancelled before it commits.
+func ImportData(ctx context.Context, csvFilePath string, db *bun.DB, options ImportOptions) (*ImportResult, error) {
+	logger := logging.OrDefault(options.Logger)
+
+	banks, err := parseCSVFile(csvFilePath, logger)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
 
 	err = db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
-		_, err := tx.NewInsert().Model(&banks).Exec(ctx)
+		countBefore, err := tx.NewSelect().Model((*models.Swift)(nil)).Count(ctx)
+		if err != nil {
+			return err
+		}
+
+		affected := 0
+		if len(banks) > 0 {
+			query := tx.NewInsert().Model(&banks).On("CONFLICT (swift_code) DO UPDATE")
+			for _, column := range upsertColumns {
+				query = query.Set("? = EXCLUDED.?", bun.Ident(column), bun.Ident(column))
+			}
+			// Rows that didn't change are skipped, so they aren't counted as
+			// updated. Deleted rows in the file are restored and counted as inserted.
+			res, err := query.
+				Set("deleted_at = NULL").
+				Where("(?) IS DISTINCT FROM (?) OR s.deleted_at IS NOT NULL",
+					bun.Safe("s."+strings.Join(upsertColumns, ", s.")),
+					bun.Safe("EXCLUDED."+strings.Join(upsertColumns, ", EXCLUDED."))).
+				Returning("NULL").
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+			rows, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			affected = int(rows)
+		}
+
+		countAfter, err := tx.NewSelect().Model((*models.Swift)(nil)).Count(ctx)
+		if err != nil {
+			return err
+		}
+
+		result.Inserted = countAfter - countBefore
+		result.Updated = affected - result.Inserted
+		result.Unchanged = len(banks) - affected
+
+		if options.Prune {
+			result.Deleted, err = pruneSwifts(ctx, tx, banks)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.NewRaw(linkBranchesQuery).Exec(ctx)
 		return err
 	})
 
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	result.record()
+	result.log(logger, csvFilePath)
+	return result, nil
+}
+
+// pruneRepo soft deletes the swift codes of swiftRepo that are not in banks,
+// like pruneSwifts.
+func pruneRepo(ctx context.Context, swiftRepo repositories.SwiftRepo, banks []models.Swift) (int, error) {
+	inFile := make(map[string]struct{}, len(banks))
+	for i := range banks {
+		inFile[banks[i].SwiftCode] = struct{}{}
+	}
+
+	var stale []string
+	err := swiftRepo.ForEachSwift(ctx, "", func(swift *models.Swift) error {
+		if _, ok := inFile[swift.SwiftCode]; !ok {
+			stale = append(stale, swift.SwiftCode)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, swiftCode := range stale {
+		if models.IsSwiftCodeOfHeadquarter(swiftCode) {
+			if err := swiftRepo.UnlinkBranches(ctx, swiftCode); err != nil {
+				return 0, err
+			}
+		}
+		if err := swiftRepo.DeleteSwift(ctx, swiftCode); err != nil {
+			return 0, err
+		}
+	}
+	return len(stale), nil
+}
+
+// ImportDataToRepo imports a CSV file through swiftRepo. It stops between rows
+// once ctx is cancelled, keeping the rows imported so far.
+func ImportDataToRepo(ctx context.Context, csvFilePath string, swiftRepo repositories.SwiftRepo, options ImportOptions) (*ImportResult, error) {
+	logger := logging.OrDefault(options.Logger)
+
+	banks, err := parseCSVFile(csvFilePath, logger)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+	headquarterCodes := make(map[string]struct{})
+
+	for i := range banks {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if !models.IsSwiftCodeOfHeadquarter(banks[i].SwiftCode) {
+			headquarterCodes[models.HeadquarterCodeOf(banks[i].SwiftCode)] = struct{}{}
+		}
+
+		existing, err := swiftRepo.GetBySwiftCode(ctx, banks[i].SwiftCode)
+		if err == nil {
+			banks[i].HeadquarterCode = existing.HeadquarterCode
+			banks[i].HeadquarterMissing = existing.HeadquarterMissing
+			banks[i].UpdatedAt = existing.UpdatedAt
+		}
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			err = swiftRepo.AddSwift(ctx, &banks[i])
+			result.Inserted++
+		case err != nil:
+		case *existing == banks[i]:
+			result.Unchanged++
+		default:
+			err = swiftRepo.UpdateSwift(ctx, &banks[i])
+			result.Updated++
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options.Prune {
+		result.Deleted, err = pruneRepo(ctx, swiftRepo, banks)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for headquarterCode := range headquarterCodes {
+		if err := swiftRepo.LinkBranches(ctx, headquarterCode); err != nil {
+			return nil, err
+		}
 	}
 
-	fmt.Println("Import data successfully")
-	return nil
+	result.record()
+	result.log(logger, csvFilePath)
+	return result, nil
 }
@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"awesomeProject/metrics"
+	"awesomeProject/models"
+	"awesomeProject/repositories"
+	"context"
+	"database/sql"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testCSVHeader = "COUNTRY ISO2 CODE,SWIFT CODE,CODE TYPE,NAME,ADDRESS,TOWN NAME,COUNTRY NAME,TIME ZONE\n"
+
+func writeCSVFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	err := os.WriteFile(path, []byte(testCSVHeader+content), 0o644)
+	assert.NoError(t, err)
+	return path
+}
+
+func TestParseCSVFile(t *testing.T) {
+	path := writeCSVFile(t, `al,aaisaltrxxx,BIC11,UNITED BANK OF ALBANIA SH.A,"HYRJA 3, TIRANA",TIRANA,albania,Europe/Tirane`+"\n"+
+		`BG,ABIEBGS1XXX,BIC11,ABV INVESTMENTS LTD,"TSAR ASEN 20, VARNA",VARNA,BULGARIA,Europe/Sofia`+"\n"+
+		`BG,SHORT,BIC11,TOO SHORT,ADDRESS,VARNA,BULGARIA,Europe/Sofia`+"\n"+
+		`BG,ABIEBGS1XXX,BIC11,ABV INVESTMENTS LTD,"TSAR ASEN 22, VARNA",VARNA,BULGARIA,Europe/Sofia`+"\n")
+
+	swifts, err := parseCSVFile(path, slog.Default())
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Swift{
+		{
+			CountryIso2:   "AL",
+			SwiftCode:     "AAISALTRXXX",
+			CodeType:      "BIC11",
+			BankName:      "UNITED BANK OF ALBANIA SH.A",
+			Address:       "HYRJA 3, TIRANA",
+			TownName:      "TIRANA",
+			CountryName:   "ALBANIA",
+			TimeZone:      "Europe/Tirane",
+			IsHeadquarter: true,
+		},
+		{
+			CountryIso2:   "BG",
+			SwiftCode:     "ABIEBGS1XXX",
+			CodeType:      "BIC11",
+			BankName:      "ABV INVESTMENTS LTD",
+			Address:       "TSAR ASEN 22, VARNA",
+			TownName:      "VARNA",
+			CountryName:   "BULGARIA",
+			TimeZone:      "Europe/Sofia",
+			IsHeadquarter: true,
+		},
+	}, swifts)
+}
+
+func TestImportDataToRepo(t *testing.T) {
+	repo := repositories.NewSwiftRepoMemory()
+
+	path := writeCSVFile(t, `AL,AAISALTRXXX,BIC11,UNITED BANK OF ALBANIA SH.A,"HYRJA 3, TIRANA",TIRANA,ALBANIA,Europe/Tirane`+"\n"+
+		`BG,ABIEBGS1XXX,BIC11,ABV INVESTMENTS LTD,"TSAR ASEN 20, VARNA",VARNA,BULGARIA,Europe/Sofia`+"\n")
+
+	inserted := testutil.ToFloat64(metrics.ImportedRows.WithLabelValues("inserted"))
+	unchanged := testutil.ToFloat64(metrics.ImportedRows.WithLabelValues("unchanged"))
+
+	result, err := ImportDataToRepo(context.Background(), path, repo, ImportOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, &ImportResult{Inserted: 2}, result)
+
+	result, err = ImportDataToRepo(context.Background(), path, repo, ImportOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, &ImportResult{Unchanged: 2}, result)
+	assert.Equal(t, inserted+2, testutil.ToFloat64(metrics.ImportedRows.WithLabelValues("inserted")))
+	assert.Equal(t, unchanged+2, testutil.ToFloat64(metrics.ImportedRows.WithLabelValues("unchanged")))
+
+	path = writeCSVFile(t, `AL,AAISALTRXXX,BIC11,UNITED BANK OF ALBANIA SH.A,"HYRJA 3, TIRANA",TIRANA,ALBANIA,Europe/Tirane`+"\n"+
+		`BG,ABIEBGS1XXX,BIC11,ABV INVESTMENTS LTD,"TSAR ASEN 22, VARNA",VARNA,BULGARIA,Europe/Sofia`+"\n"+
+		`BG,ABIEBGS1001,BIC11,ABV INVESTMENTS LTD,"TSAR ASEN 24, VARNA",VARNA,BULGARIA,Europe/Sofia`+"\n")
+
+	result, err = ImportDataToRepo(context.Background(), path, repo, ImportOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, &ImportResult{Inserted: 1, Updated: 1, Unchanged: 1}, result)
+
+	swift, err := repo.GetBySwiftCode(context.Background(), "ABIEBGS1XXX")
+	assert.NoError(t, err)
+	assert.Equal(t, "TSAR ASEN 22, VARNA", swift.Address)
+
+	branch, err := repo.GetBySwiftCode(context.Background(), "ABIEBGS1001")
+	assert.NoError(t, err)
+	assert.Equal(t, "ABIEBGS1XXX", branch.HeadquarterCode)
+
+	result, err = ImportDataToRepo(context.Background(), path, repo, ImportOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, &ImportResult{Unchanged: 3}, result)
+}
+
+func TestImportDataToRepo_Cancelled(t *testing.T) {
+	repo := repositories.NewSwiftRepoMemory()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	path := writeCSVFile(t, `BG,ABIEBGS1XXX,BIC11,ABV INVESTMENTS LTD,"TSAR ASEN 20, VARNA",VARNA,BULGARIA,Europe/Sofia`+"\n")
+	_, err := ImportDataToRepo(ctx, path, repo, ImportOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = repo.GetBySwiftCode(context.Background(), "ABIEBGS1XXX")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestImportDataToRepo_Prune(t *testing.T) {
+	repo := repositories.NewSwiftRepoMemory()
+	ctx := context.Background()
+
+	path := writeCSVFile(t, `BG,ABIEBGS1XXX,BIC11,ABV INVESTMENTS LTD,"TSAR ASEN 20, VARNA",VARNA,BULGARIA,Europe/Sofia`+"\n"+
+		`BG,ABIEBGS1001,BIC11,ABV INVESTMENTS LTD,"TSAR ASEN 24, VARNA",VARNA,BULGARIA,Europe/Sofia`+"\n"+
+		`AL,AAISALTRXXX,BIC11,UNITED BANK OF ALBANIA SH.A,"HYRJA 3, TIRANA",TIRANA,ALBANIA,Europe/Tirane`+"\n")
+	_, err := ImportDataToRepo(ctx, path, repo, ImportOptions{})
+	assert.NoError(t, err)
+
+	// The headquarter is gone from the file, its branch is not.
+	path = writeCSVFile(t, `BG,ABIEBGS1001,BIC11,ABV INVESTMENTS LTD,"TSAR ASEN 24, VARNA",VARNA,BULGARIA,Europe/Sofia`+"\n"+
+		`AL,AAISALTRXXX,BIC11,UNITED BANK OF ALBANIA SH.A,"HYRJA 3, TIRANA",TIRANA,ALBANIA,Europe/Tirane`+"\n")
+
+	result, err := ImportDataToRepo(ctx, path, repo, ImportOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, &ImportResult{Unchanged: 2}, result)
+	_, err = repo.GetBySwiftCode(ctx, "ABIEBGS1XXX")
+	assert.NoError(t, err)
+
+	result, err = ImportDataToRepo(ctx, path, repo, ImportOptions{Prune: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &ImportResult{Unchanged: 2, Deleted: 1}, result)
+
+	_, err = repo.GetBySwiftCode(ctx, "ABIEBGS1XXX")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	branch, err := repo.GetBySwiftCode(ctx, "ABIEBGS1001")
+	assert.NoError(t, err)
+	assert.Empty(t, branch.HeadquarterCode)
+}
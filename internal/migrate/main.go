@@ -0,0 +1,83 @@
+package main
+
+import (
+	"awesomeProject/configs"
+	"awesomeProject/dbs"
+	"awesomeProject/dbs/migrations"
+	"flag"
+	"fmt"
+	"github.com/uptrace/bun"
+	"os"
+)
+
+const usage = "Usage: go run ./internal/migrate [-config <file>] up|rollback|status"
+
+func main() {
+	configPath := flag.String("config", "", "path to a YAML config file (defaults to $"+configs.ConfigFileEnv+")")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	config, err := configs.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if config.Repository != configs.RepositoryPostgres {
+		fmt.Fprintln(os.Stderr, "migrations only apply to the postgres repository")
+		os.Exit(1)
+	}
+
+	db := dbs.Connect(
+		&config.DBConfig,
+	)
+
+	defer func(db *bun.DB) {
+		err := db.Close()
+		if err != nil {
+			fmt.Println("Error closing db")
+		}
+	}(db)
+
+	err = run(db, flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(db *bun.DB, command string) error {
+	switch command {
+	case "up":
+		return migrations.Migrate(db)
+	case "rollback":
+		group, err := migrations.Rollback(db)
+		if err != nil {
+			return err
+		}
+		if group.IsZero() {
+			fmt.Println("No migrations to roll back")
+			return nil
+		}
+		fmt.Printf("Rolled back %s\n", group)
+		return nil
+	case "status":
+		status, err := migrations.Status(db)
+		if err != nil {
+			return err
+		}
+		for _, migration := range status {
+			state := "pending"
+			if migration.IsApplied() {
+				state = fmt.Sprintf("applied in group #%d at %s", migration.GroupID, migration.MigratedAt.Format("2006-01-02 15:04:05"))
+			}
+			fmt.Printf("%s\t%s\n", migration, state)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q\n%s", command, usage)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"awesomeProject/configs"
+	"awesomeProject/dbs"
+	"awesomeProject/repositories"
+	"context"
+	"flag"
+	"fmt"
+	"github.com/uptrace/bun"
+	"os"
+	"time"
+)
+
+func main() {
+	configPath := flag.String("config", "", "path to a YAML config file (defaults to $"+configs.ConfigFileEnv+")")
+	days := flag.Int("days", 30, "purge swift codes deleted more than this many days ago")
+	flag.Parse()
+
+	if *days < 0 {
+		fmt.Fprintln(os.Stderr, "-days must not be negative")
+		os.Exit(2)
+	}
+
+	config, err := configs.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if config.Repository != configs.RepositoryPostgres {
+		fmt.Fprintln(os.Stderr, "purging only applies to the postgres repository")
+		os.Exit(1)
+	}
+
+	db := dbs.Connect(
+		&config.DBConfig,
+	)
+
+	defer func(db *bun.DB) {
+		err := db.Close()
+		if err != nil {
+			fmt.Println("Error closing db")
+		}
+	}(db)
+
+	swiftRepo := repositories.SwiftRepoPostgres{Db: &dbs.BunDBWrapper{DB: db}}
+	deletedBefore := time.Now().AddDate(0, 0, -*days)
+
+	purged, err := swiftRepo.PurgeDeleted(context.Background(), deletedBefore)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Purged %d swift codes deleted before %s\n", purged, deletedBefore.Format(time.DateTime))
+}
@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"awesomeProject/models"
+	"context"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"log/slog"
+)
+
+// New returns a JSON logger writing records at level and above to w. Records
+// logged with a request context carry its request ID and trace.
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(contextHandler{slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})})
+}
+
+// OrDefault returns logger, or slog.Default() when it is nil, so that types
+// with an optional Logger field work without one.
+func OrDefault(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return slog.Default()
+	}
+	return logger
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (handler contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := models.RequestIDFrom(ctx); requestID != "" {
+		record.AddAttrs(slog.String("requestId", requestID))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("traceId", spanContext.TraceID().String()),
+			slog.String("spanId", spanContext.SpanID().String()),
+		)
+	}
+	return handler.Handler.Handle(ctx, record)
+}
+
+func (handler contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{handler.Handler.WithAttrs(attrs)}
+}
+
+func (handler contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{handler.Handler.WithGroup(name)}
+}
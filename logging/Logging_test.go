@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"awesomeProject/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	var out bytes.Buffer
+	logger := New(&out, slog.LevelInfo).With("component", "test")
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := models.WithRequestID(context.Background(), "request-1")
+	ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+	logger.DebugContext(ctx, "hidden")
+	logger.InfoContext(ctx, "shown", "swiftCode", "AAAAAAAAXXX")
+	logger.Info("without context")
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+
+	var record map[string]any
+	assert.NoError(t, json.Unmarshal(lines[0], &record))
+	assert.Equal(t, "shown", record["msg"])
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, "test", record["component"])
+	assert.Equal(t, "AAAAAAAAXXX", record["swiftCode"])
+	assert.Equal(t, "request-1", record["requestId"])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", record["traceId"])
+	assert.Equal(t, "00f067aa0ba902b7", record["spanId"])
+
+	record = nil
+	assert.NoError(t, json.Unmarshal(lines[1], &record))
+	assert.NotContains(t, record, "requestId")
+	assert.NotContains(t, record, "traceId")
+}
+
+func TestOrDefault(t *testing.T) {
+	assert.Equal(t, slog.Default(), OrDefault(nil))
+
+	logger := New(&bytes.Buffer{}, slog.LevelInfo)
+	assert.Equal(t, logger, OrDefault(logger))
+}
@@ -1,63 +1,230 @@
 package main
 
 import (
+	"awesomeProject/cache"
 	"awesomeProject/configs"
 	"awesomeProject/controllers"
 	"awesomeProject/dbs"
 	"awesomeProject/dbs/migrations"
+	"awesomeProject/health"
 	"awesomeProject/internal/dbimporter/utils"
+	"awesomeProject/logging"
+	"awesomeProject/metrics"
 	"awesomeProject/models"
 	"awesomeProject/repositories"
 	"awesomeProject/routes"
 	"awesomeProject/services"
+	"awesomeProject/tracing"
+	"context"
+	"flag"
 	"fmt"
-	"github.com/go-playground/validator/v10"
 	"github.com/uptrace/bun"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
 func main() {
-	config := configs.GetConfig()
+	configPath := flag.String("config", "", "path to a YAML config file (defaults to $"+configs.ConfigFileEnv+")")
+	printConfig := flag.Bool("print-config", false, "print the effective config with secrets masked and exit")
+	flag.Parse()
 
-	db := dbs.Connect(
-		&config.DBConfig,
-	)
+	config, err := configs.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *printConfig {
+		fmt.Print(config)
+		return
+	}
+
+	logger := logging.New(os.Stdout, config.Log.SlogLevel())
+	slog.SetDefault(logger)
 
-	defer func(db *bun.DB) {
-		err := db.Close()
+	if config.Tracing.Enabled {
+		shutdownTracing, err := tracing.Setup(context.Background(), config.Tracing)
 		if err != nil {
 			panic(err)
 		}
-	}(db)
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), config.Server.ShutdownTimeout)
+			defer cancel()
+			if err := shutdownTracing(shutdownCtx); err != nil {
+				logger.Error("flushing spans", "error", err)
+			}
+		}()
+	}
 
-	err := migrations.Migrate(db)
-	if err != nil {
-		panic(err)
+	var swiftRepo repositories.SwiftRepo
+	var importTask health.Task
+	var importOnStartup func(ctx context.Context) error
+	healthChecks := map[string]health.Check{"import": importTask.Check}
+	routerOptions := []routes.Option{
+		routes.WithFeatures(config.Features),
+		routes.WithRateLimit(config.RateLimit),
+		routes.WithTrustedProxies(config.Server.TrustedProxies),
+		routes.WithMetrics(config.Metrics),
+		routes.WithTracing(config.Tracing),
+		routes.WithLogger(logger),
 	}
 
-	err = utils.ImportData("./data.csv", db)
-	if err != nil {
-		fmt.Println(err)
+	switch config.Repository {
+	case configs.RepositoryMemory:
+		memoryRepo := repositories.NewSwiftRepoMemory()
+
+		if config.Import.OnStartup {
+			importOnStartup = func(ctx context.Context) error {
+				_, err := utils.ImportDataToRepo(ctx, config.Import.Path, memoryRepo, utils.ImportOptions{Prune: config.Import.Prune, Logger: logger})
+				return err
+			}
+		}
+
+		swiftRepo = memoryRepo
+	case configs.RepositoryPostgres:
+		db := dbs.Connect(
+			&config.DBConfig,
+		)
+
+		defer func(db *bun.DB) {
+			err := db.Close()
+			if err != nil {
+				logger.Error("closing database", "error", err)
+			}
+		}(db)
+
+		err := migrations.Migrate(db)
+		if err != nil {
+			panic(err)
+		}
+
+		healthChecks["database"] = health.Database(db)
+		healthChecks["migrations"] = health.Migrations(db)
+
+		if config.Import.OnStartup {
+			importOnStartup = func(ctx context.Context) error {
+				_, err := utils.ImportData(ctx, config.Import.Path, db, utils.ImportOptions{Prune: config.Import.Prune, Logger: logger})
+				return err
+			}
+		}
+
+		swiftRepo = &repositories.SwiftRepoPostgres{
+			Db:     &dbs.BunDBWrapper{DB: db},
+			Logger: logger,
+		}
+		if config.Cache.Enabled {
+			cachedRepo := repositories.NewSwiftRepoCached(swiftRepo, cache.NewLRU(config.Cache.Size), config.Cache.TTL)
+			metrics.RegisterCache(cachedRepo.Stats)
+			swiftRepo = cachedRepo
+		}
+
+		if config.Auth.Enabled && config.Auth.ApiKeys {
+			routerOptions = append(routerOptions, routes.WithApiKeys(repositories.ApiKeyRepoPostgres{
+				Db: &dbs.BunDBWrapper{DB: db},
+			}))
+		}
 	}
 
-	swiftRepo := &repositories.SwiftRepoPostgres{
-		Db: &dbs.BunDBWrapper{DB: db},
+	if config.Auth.Enabled && config.Auth.JWT.Enabled {
+		jwtAuthenticator, err := routes.NewJWTAuthenticator(config.Auth.JWT)
+		if err != nil {
+			panic(err)
+		}
+		routerOptions = append(routerOptions, routes.WithAuthenticator(jwtAuthenticator))
 	}
 
-	validate := validator.New()
-	validate.RegisterStructValidation(models.SwiftStructLevelValidation, models.Swift{})
+	validate := models.NewSwiftValidator()
+
+	swiftService := services.SwiftServiceDefault{
+		MissingHeadquarter: config.Branches.MissingHeadquarter,
+		HeadquarterDelete:  config.Branches.OnHeadquarterDelete,
+		Logger:             logger,
+	}
 
-	swiftService := services.SwiftServiceDefault{}
+	var service services.SwiftService = &swiftService
+	if config.Tracing.Enabled {
+		service = services.NewSwiftServiceTraced(service)
+	}
 
 	swiftController := controllers.Controller{
-		SwiftService: &swiftService,
+		SwiftService: service,
 		SwiftRepo:    swiftRepo,
 		Validate:     validate,
+		Logger:       logger,
+	}
+
+	routerOptions = append(routerOptions, routes.WithHealthChecks(healthChecks))
+	router := routes.SetupRouter(&swiftController, routerOptions...)
+
+	server := &http.Server{
+		Addr:              config.Server.Address,
+		Handler:           router,
+		ReadTimeout:       config.Server.ReadTimeout,
+		ReadHeaderTimeout: config.Server.ReadHeaderTimeout,
+		WriteTimeout:      config.Server.WriteTimeout,
+		IdleTimeout:       config.Server.IdleTimeout,
 	}
 
-	router := routes.SetupRouter(&swiftController)
-	err = router.Run(":8080")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// The import runs while the server starts; /readyz fails until it is done,
+	// and for good if it fails.
+	importDone := make(chan struct{})
+	go func() {
+		defer close(importDone)
+		if importOnStartup == nil {
+			importTask.Finish()
+			return
+		}
+		if err := importTask.Run(func() error { return importOnStartup(ctx) }); err != nil {
+			logger.Error("importing on startup", "file", config.Import.Path, "error", err)
+		}
+	}()
 
+	err = serve(ctx, server, config.Server.ShutdownTimeout, logger)
+	// The import uses the database closed on return, so it is cancelled and
+	// awaited first.
+	stop()
+	select {
+	case <-importDone:
+	case <-time.After(config.Server.ShutdownTimeout):
+		logger.Warn("import still running at shutdown", "file", config.Import.Path)
+	}
 	if err != nil {
 		panic(err)
 	}
 }
+
+// serve runs the server until ctx is cancelled, then stops accepting connections and
+// waits up to shutdownTimeout for in-flight requests before returning.
+func serve(ctx context.Context, server *http.Server, shutdownTimeout time.Duration, logger *slog.Logger) error {
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+	logger.Info("server listening", "address", server.Addr)
+
+	select {
+	case err := <-serverErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Info("shutting down server")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err := server.Shutdown(shutdownCtx)
+	if err != nil {
+		return fmt.Errorf("server shutdown: %w", err)
+	}
+
+	logger.Info("server stopped")
+	return nil
+}
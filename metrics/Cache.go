@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"awesomeProject/cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"sync"
+)
+
+var cacheLookupsDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "cache", "lookups_total"),
+	"Cached lookups by result: hit or miss.",
+	[]string{"result"}, nil,
+)
+
+// cacheCollector reads the counters of a cache when scraped, so they are
+// kept in one place.
+type cacheCollector struct {
+	stats func() cache.Stats
+}
+
+func (collector cacheCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- cacheLookupsDesc
+}
+
+func (collector cacheCollector) Collect(metrics chan<- prometheus.Metric) {
+	stats := collector.stats()
+	metrics <- prometheus.MustNewConstMetric(cacheLookupsDesc, prometheus.CounterValue, float64(stats.Hits), "hit")
+	metrics <- prometheus.MustNewConstMetric(cacheLookupsDesc, prometheus.CounterValue, float64(stats.Misses), "miss")
+}
+
+var (
+	cacheStatsMu        sync.Mutex
+	cacheStatsCollector prometheus.Collector
+)
+
+// RegisterCache reports the hits and misses returned by stats, replacing the
+// cache registered before, if any.
+func RegisterCache(stats func() cache.Stats) {
+	cacheStatsMu.Lock()
+	defer cacheStatsMu.Unlock()
+
+	if cacheStatsCollector != nil {
+		Registry.Unregister(cacheStatsCollector)
+	}
+	cacheStatsCollector = cacheCollector{stats: stats}
+	Registry.MustRegister(cacheStatsCollector)
+}
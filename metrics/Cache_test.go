@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"awesomeProject/cache"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestRegisterCache(t *testing.T) {
+	RegisterCache(func() cache.Stats { return cache.Stats{Hits: 1, Misses: 1} })
+	RegisterCache(func() cache.Stats { return cache.Stats{Hits: 3, Misses: 2} })
+
+	expected := `
+# HELP swift_codes_cache_lookups_total Cached lookups by result: hit or miss.
+# TYPE swift_codes_cache_lookups_total counter
+swift_codes_cache_lookups_total{result="hit"} 3
+swift_codes_cache_lookups_total{result="miss"} 2
+`
+	assert.NoError(t, testutil.GatherAndCompare(Registry, strings.NewReader(expected), "swift_codes_cache_lookups_total"))
+}
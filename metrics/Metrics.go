@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"database/sql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"sync"
+)
+
+const namespace = "swift_codes"
+
+// Registry holds every metric of the service, served by routes on /metrics.
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Duration of HTTP requests by route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route and status code.",
+	}, []string{"method", "route", "status"})
+
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Duration of database queries by operation and result.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "status"})
+
+	ImportedRows = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "import_rows_total",
+		Help:      "Rows read by the importer by outcome: inserted, updated, unchanged or deleted.",
+	}, []string{"outcome"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		HTTPRequestsTotal,
+		DBQueryDuration,
+		ImportedRows,
+	)
+}
+
+var (
+	dbStatsMu        sync.Mutex
+	dbStatsCollector prometheus.Collector
+)
+
+// RegisterDB reports the connection pool stats of db, replacing the pool
+// registered before, if any.
+func RegisterDB(db *sql.DB) {
+	dbStatsMu.Lock()
+	defer dbStatsMu.Unlock()
+
+	if dbStatsCollector != nil {
+		Registry.Unregister(dbStatsCollector)
+	}
+	dbStatsCollector = collectors.NewDBStatsCollector(db, namespace)
+	Registry.MustRegister(dbStatsCollector)
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+// QueryHook times every query bun runs. A query that finds no rows is not an
+// error.
+type QueryHook struct{}
+
+var _ bun.QueryHook = QueryHook{}
+
+func (QueryHook) BeforeQuery(ctx context.Context, _ *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+func (QueryHook) AfterQuery(_ context.Context, event *bun.QueryEvent) {
+	status := "ok"
+	if event.Err != nil && !errors.Is(event.Err, sql.ErrNoRows) {
+		status = "error"
+	}
+	DBQueryDuration.WithLabelValues(event.Operation(), status).Observe(time.Since(event.StartTime).Seconds())
+}
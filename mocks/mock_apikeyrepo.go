@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repositories/ApiKey.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repositories/ApiKey.go -destination=mocks/mock_apikeyrepo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "awesomeProject/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockApiKeyRepo is a mock of ApiKeyRepo interface.
+type MockApiKeyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiKeyRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockApiKeyRepoMockRecorder is the mock recorder for MockApiKeyRepo.
+type MockApiKeyRepoMockRecorder struct {
+	mock *MockApiKeyRepo
+}
+
+// NewMockApiKeyRepo creates a new mock instance.
+func NewMockApiKeyRepo(ctrl *gomock.Controller) *MockApiKeyRepo {
+	mock := &MockApiKeyRepo{ctrl: ctrl}
+	mock.recorder = &MockApiKeyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiKeyRepo) EXPECT() *MockApiKeyRepoMockRecorder {
+	return m.recorder
+}
+
+// AddApiKey mocks base method.
+func (m *MockApiKeyRepo) AddApiKey(ctx context.Context, apiKey *models.ApiKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddApiKey", ctx, apiKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddApiKey indicates an expected call of AddApiKey.
+func (mr *MockApiKeyRepoMockRecorder) AddApiKey(ctx, apiKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddApiKey", reflect.TypeOf((*MockApiKeyRepo)(nil).AddApiKey), ctx, apiKey)
+}
+
+// GetAll mocks base method.
+func (m *MockApiKeyRepo) GetAll(ctx context.Context) ([]models.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]models.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockApiKeyRepoMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockApiKeyRepo)(nil).GetAll), ctx)
+}
+
+// GetByHash mocks base method.
+func (m *MockApiKeyRepo) GetByHash(ctx context.Context, keyHash string) (*models.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, keyHash)
+	ret0, _ := ret[0].(*models.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockApiKeyRepoMockRecorder) GetByHash(ctx, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockApiKeyRepo)(nil).GetByHash), ctx, keyHash)
+}
+
+// RevokeApiKey mocks base method.
+func (m *MockApiKeyRepo) RevokeApiKey(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeApiKey", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockApiKeyRepoMockRecorder) RevokeApiKey(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockApiKeyRepo)(nil).RevokeApiKey), ctx, name)
+}
@@ -11,8 +11,10 @@ package mocks
 
 import (
 	models "awesomeProject/models"
+	repositories "awesomeProject/repositories"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,6 +43,20 @@ func (m *MockSwiftRepo) EXPECT() *MockSwiftRepoMockRecorder {
 	return m.recorder
 }
 
+// AddAudit mocks base method.
+func (m *MockSwiftRepo) AddAudit(ctx context.Context, audit *models.SwiftAudit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAudit", ctx, audit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAudit indicates an expected call of AddAudit.
+func (mr *MockSwiftRepoMockRecorder) AddAudit(ctx, audit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAudit", reflect.TypeOf((*MockSwiftRepo)(nil).AddAudit), ctx, audit)
+}
+
 // AddSwift mocks base method.
 func (m *MockSwiftRepo) AddSwift(arg0 context.Context, arg1 *models.Swift) error {
 	m.ctrl.T.Helper()
@@ -55,6 +71,20 @@ func (mr *MockSwiftRepoMockRecorder) AddSwift(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSwift", reflect.TypeOf((*MockSwiftRepo)(nil).AddSwift), arg0, arg1)
 }
 
+// DeleteBranches mocks base method.
+func (m *MockSwiftRepo) DeleteBranches(ctx context.Context, headquarterCode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBranches", ctx, headquarterCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBranches indicates an expected call of DeleteBranches.
+func (mr *MockSwiftRepoMockRecorder) DeleteBranches(ctx, headquarterCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBranches", reflect.TypeOf((*MockSwiftRepo)(nil).DeleteBranches), ctx, headquarterCode)
+}
+
 // DeleteSwift mocks base method.
 func (m *MockSwiftRepo) DeleteSwift(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -69,6 +99,50 @@ func (mr *MockSwiftRepoMockRecorder) DeleteSwift(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSwift", reflect.TypeOf((*MockSwiftRepo)(nil).DeleteSwift), arg0, arg1)
 }
 
+// ForEachSwift mocks base method.
+func (m *MockSwiftRepo) ForEachSwift(ctx context.Context, countryIso2Code string, fn func(*models.Swift) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForEachSwift", ctx, countryIso2Code, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForEachSwift indicates an expected call of ForEachSwift.
+func (mr *MockSwiftRepoMockRecorder) ForEachSwift(ctx, countryIso2Code, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEachSwift", reflect.TypeOf((*MockSwiftRepo)(nil).ForEachSwift), ctx, countryIso2Code, fn)
+}
+
+// GetAuditBySwiftCode mocks base method.
+func (m *MockSwiftRepo) GetAuditBySwiftCode(ctx context.Context, swiftCode string) ([]models.SwiftAudit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditBySwiftCode", ctx, swiftCode)
+	ret0, _ := ret[0].([]models.SwiftAudit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditBySwiftCode indicates an expected call of GetAuditBySwiftCode.
+func (mr *MockSwiftRepoMockRecorder) GetAuditBySwiftCode(ctx, swiftCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditBySwiftCode", reflect.TypeOf((*MockSwiftRepo)(nil).GetAuditBySwiftCode), ctx, swiftCode)
+}
+
+// GetBranchParent mocks base method.
+func (m *MockSwiftRepo) GetBranchParent(ctx context.Context, branchCode string) (*models.BranchParent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranchParent", ctx, branchCode)
+	ret0, _ := ret[0].(*models.BranchParent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBranchParent indicates an expected call of GetBranchParent.
+func (mr *MockSwiftRepoMockRecorder) GetBranchParent(ctx, branchCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranchParent", reflect.TypeOf((*MockSwiftRepo)(nil).GetBranchParent), ctx, branchCode)
+}
+
 // GetBranchesBySwiftCode mocks base method.
 func (m *MockSwiftRepo) GetBranchesBySwiftCode(arg0 context.Context, arg1 string) ([]models.SwiftMini, error) {
 	m.ctrl.T.Helper()
@@ -85,18 +159,18 @@ func (mr *MockSwiftRepoMockRecorder) GetBranchesBySwiftCode(arg0, arg1 any) *gom
 }
 
 // GetByCountryIso2Code mocks base method.
-func (m *MockSwiftRepo) GetByCountryIso2Code(arg0 context.Context, arg1 string) ([]models.SwiftMini, error) {
+func (m *MockSwiftRepo) GetByCountryIso2Code(arg0 context.Context, arg1 string, arg2 models.PageRequest) ([]models.SwiftMini, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByCountryIso2Code", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetByCountryIso2Code", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]models.SwiftMini)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByCountryIso2Code indicates an expected call of GetByCountryIso2Code.
-func (mr *MockSwiftRepoMockRecorder) GetByCountryIso2Code(arg0, arg1 any) *gomock.Call {
+func (mr *MockSwiftRepoMockRecorder) GetByCountryIso2Code(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCountryIso2Code", reflect.TypeOf((*MockSwiftRepo)(nil).GetByCountryIso2Code), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCountryIso2Code", reflect.TypeOf((*MockSwiftRepo)(nil).GetByCountryIso2Code), arg0, arg1, arg2)
 }
 
 // GetBySwiftCode mocks base method.
@@ -128,3 +202,148 @@ func (mr *MockSwiftRepoMockRecorder) GetCountryNameByIso2Code(arg0, arg1 any) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountryNameByIso2Code", reflect.TypeOf((*MockSwiftRepo)(nil).GetCountryNameByIso2Code), arg0, arg1)
 }
+
+// GetCountryVersion mocks base method.
+func (m *MockSwiftRepo) GetCountryVersion(ctx context.Context, countryIso2Code string) (models.CountryVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountryVersion", ctx, countryIso2Code)
+	ret0, _ := ret[0].(models.CountryVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountryVersion indicates an expected call of GetCountryVersion.
+func (mr *MockSwiftRepoMockRecorder) GetCountryVersion(ctx, countryIso2Code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountryVersion", reflect.TypeOf((*MockSwiftRepo)(nil).GetCountryVersion), ctx, countryIso2Code)
+}
+
+// GetSwiftVersion mocks base method.
+func (m *MockSwiftRepo) GetSwiftVersion(ctx context.Context, swiftCode string) (models.SwiftVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSwiftVersion", ctx, swiftCode)
+	ret0, _ := ret[0].(models.SwiftVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSwiftVersion indicates an expected call of GetSwiftVersion.
+func (mr *MockSwiftRepoMockRecorder) GetSwiftVersion(ctx, swiftCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwiftVersion", reflect.TypeOf((*MockSwiftRepo)(nil).GetSwiftVersion), ctx, swiftCode)
+}
+
+// LinkBranches mocks base method.
+func (m *MockSwiftRepo) LinkBranches(ctx context.Context, headquarterCode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkBranches", ctx, headquarterCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkBranches indicates an expected call of LinkBranches.
+func (mr *MockSwiftRepoMockRecorder) LinkBranches(ctx, headquarterCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkBranches", reflect.TypeOf((*MockSwiftRepo)(nil).LinkBranches), ctx, headquarterCode)
+}
+
+// LockCountryVersion mocks base method.
+func (m *MockSwiftRepo) LockCountryVersion(ctx context.Context, countryIso2Code string) (models.CountryVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCountryVersion", ctx, countryIso2Code)
+	ret0, _ := ret[0].(models.CountryVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockCountryVersion indicates an expected call of LockCountryVersion.
+func (mr *MockSwiftRepoMockRecorder) LockCountryVersion(ctx, countryIso2Code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCountryVersion", reflect.TypeOf((*MockSwiftRepo)(nil).LockCountryVersion), ctx, countryIso2Code)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockSwiftRepo) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, deletedBefore)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockSwiftRepoMockRecorder) PurgeDeleted(ctx, deletedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockSwiftRepo)(nil).PurgeDeleted), ctx, deletedBefore)
+}
+
+// RestoreSwift mocks base method.
+func (m *MockSwiftRepo) RestoreSwift(ctx context.Context, swiftCode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSwift", ctx, swiftCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreSwift indicates an expected call of RestoreSwift.
+func (mr *MockSwiftRepoMockRecorder) RestoreSwift(ctx, swiftCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSwift", reflect.TypeOf((*MockSwiftRepo)(nil).RestoreSwift), ctx, swiftCode)
+}
+
+// RunInTx mocks base method.
+func (m *MockSwiftRepo) RunInTx(ctx context.Context, fn func(context.Context, repositories.SwiftRepo) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunInTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunInTx indicates an expected call of RunInTx.
+func (mr *MockSwiftRepoMockRecorder) RunInTx(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunInTx", reflect.TypeOf((*MockSwiftRepo)(nil).RunInTx), ctx, fn)
+}
+
+// Search mocks base method.
+func (m *MockSwiftRepo) Search(arg0 context.Context, arg1 models.SearchQuery) ([]models.SwiftMini, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1)
+	ret0, _ := ret[0].([]models.SwiftMini)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSwiftRepoMockRecorder) Search(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSwiftRepo)(nil).Search), arg0, arg1)
+}
+
+// UnlinkBranches mocks base method.
+func (m *MockSwiftRepo) UnlinkBranches(ctx context.Context, headquarterCode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkBranches", ctx, headquarterCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkBranches indicates an expected call of UnlinkBranches.
+func (mr *MockSwiftRepoMockRecorder) UnlinkBranches(ctx, headquarterCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkBranches", reflect.TypeOf((*MockSwiftRepo)(nil).UnlinkBranches), ctx, headquarterCode)
+}
+
+// UpdateSwift mocks base method.
+func (m *MockSwiftRepo) UpdateSwift(arg0 context.Context, arg1 *models.Swift) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSwift", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSwift indicates an expected call of UpdateSwift.
+func (mr *MockSwiftRepoMockRecorder) UpdateSwift(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSwift", reflect.TypeOf((*MockSwiftRepo)(nil).UpdateSwift), arg0, arg1)
+}
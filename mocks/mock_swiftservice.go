@@ -56,28 +56,87 @@ func (mr *MockSwiftServiceMockRecorder) AddSwift(ctx, swift, swiftRepo, validate
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSwift", reflect.TypeOf((*MockSwiftService)(nil).AddSwift), ctx, swift, swiftRepo, validate)
 }
 
+// AddSwifts mocks base method.
+func (m *MockSwiftService) AddSwifts(ctx context.Context, swifts []models.Swift, atomic bool, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSwifts", ctx, swifts, atomic, swiftRepo, validate)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSwifts indicates an expected call of AddSwifts.
+func (mr *MockSwiftServiceMockRecorder) AddSwifts(ctx, swifts, atomic, swiftRepo, validate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSwifts", reflect.TypeOf((*MockSwiftService)(nil).AddSwifts), ctx, swifts, atomic, swiftRepo, validate)
+}
+
+// CheckSwifts mocks base method.
+func (m *MockSwiftService) CheckSwifts(ctx context.Context, swifts []models.Swift, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) []error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSwifts", ctx, swifts, swiftRepo, validate)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// CheckSwifts indicates an expected call of CheckSwifts.
+func (mr *MockSwiftServiceMockRecorder) CheckSwifts(ctx, swifts, swiftRepo, validate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSwifts", reflect.TypeOf((*MockSwiftService)(nil).CheckSwifts), ctx, swifts, swiftRepo, validate)
+}
+
 // DeleteSwift mocks base method.
-func (m *MockSwiftService) DeleteSwift(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) error {
+func (m *MockSwiftService) DeleteSwift(ctx context.Context, swiftCode, ifMatch string, swiftRepo repositories.SwiftRepo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSwift", ctx, swiftCode, swiftRepo)
+	ret := m.ctrl.Call(m, "DeleteSwift", ctx, swiftCode, ifMatch, swiftRepo)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteSwift indicates an expected call of DeleteSwift.
-func (mr *MockSwiftServiceMockRecorder) DeleteSwift(ctx, swiftCode, swiftRepo any) *gomock.Call {
+func (mr *MockSwiftServiceMockRecorder) DeleteSwift(ctx, swiftCode, ifMatch, swiftRepo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSwift", reflect.TypeOf((*MockSwiftService)(nil).DeleteSwift), ctx, swiftCode, swiftRepo)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSwift", reflect.TypeOf((*MockSwiftService)(nil).DeleteSwift), ctx, swiftCode, ifMatch, swiftRepo)
+}
+
+// ExportSwifts mocks base method.
+func (m *MockSwiftService) ExportSwifts(ctx context.Context, countryIso2Code string, swiftRepo repositories.SwiftRepo, write func(*models.Swift) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportSwifts", ctx, countryIso2Code, swiftRepo, write)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportSwifts indicates an expected call of ExportSwifts.
+func (mr *MockSwiftServiceMockRecorder) ExportSwifts(ctx, countryIso2Code, swiftRepo, write any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportSwifts", reflect.TypeOf((*MockSwiftService)(nil).ExportSwifts), ctx, countryIso2Code, swiftRepo, write)
+}
+
+// GetCountryVersion mocks base method.
+func (m *MockSwiftService) GetCountryVersion(ctx context.Context, countryIso2Code string, swiftRepo repositories.SwiftRepo) (models.CountryVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountryVersion", ctx, countryIso2Code, swiftRepo)
+	ret0, _ := ret[0].(models.CountryVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountryVersion indicates an expected call of GetCountryVersion.
+func (mr *MockSwiftServiceMockRecorder) GetCountryVersion(ctx, countryIso2Code, swiftRepo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountryVersion", reflect.TypeOf((*MockSwiftService)(nil).GetCountryVersion), ctx, countryIso2Code, swiftRepo)
 }
 
 // GetSwiftDetails mocks base method.
-func (m *MockSwiftService) GetSwiftDetails(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) (*models.Swift, []models.SwiftMini, error) {
+func (m *MockSwiftService) GetSwiftDetails(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) (*models.Swift, []models.SwiftMini, *models.BranchParent, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSwiftDetails", ctx, swiftCode, swiftRepo)
 	ret0, _ := ret[0].(*models.Swift)
 	ret1, _ := ret[1].([]models.SwiftMini)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret2, _ := ret[2].(*models.BranchParent)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
 }
 
 // GetSwiftDetails indicates an expected call of GetSwiftDetails.
@@ -86,18 +145,107 @@ func (mr *MockSwiftServiceMockRecorder) GetSwiftDetails(ctx, swiftCode, swiftRep
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwiftDetails", reflect.TypeOf((*MockSwiftService)(nil).GetSwiftDetails), ctx, swiftCode, swiftRepo)
 }
 
+// GetSwiftHistory mocks base method.
+func (m *MockSwiftService) GetSwiftHistory(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) ([]models.SwiftAudit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSwiftHistory", ctx, swiftCode, swiftRepo)
+	ret0, _ := ret[0].([]models.SwiftAudit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSwiftHistory indicates an expected call of GetSwiftHistory.
+func (mr *MockSwiftServiceMockRecorder) GetSwiftHistory(ctx, swiftCode, swiftRepo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwiftHistory", reflect.TypeOf((*MockSwiftService)(nil).GetSwiftHistory), ctx, swiftCode, swiftRepo)
+}
+
+// GetSwiftVersion mocks base method.
+func (m *MockSwiftService) GetSwiftVersion(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) (models.SwiftVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSwiftVersion", ctx, swiftCode, swiftRepo)
+	ret0, _ := ret[0].(models.SwiftVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSwiftVersion indicates an expected call of GetSwiftVersion.
+func (mr *MockSwiftServiceMockRecorder) GetSwiftVersion(ctx, swiftCode, swiftRepo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwiftVersion", reflect.TypeOf((*MockSwiftService)(nil).GetSwiftVersion), ctx, swiftCode, swiftRepo)
+}
+
 // GetSwiftsDetailsByCountryIso2Code mocks base method.
-func (m *MockSwiftService) GetSwiftsDetailsByCountryIso2Code(ctx context.Context, countryIso2Code string, swiftRepo repositories.SwiftRepo) (string, []models.SwiftMini, error) {
+func (m *MockSwiftService) GetSwiftsDetailsByCountryIso2Code(ctx context.Context, countryIso2Code string, page models.PageRequest, swiftRepo repositories.SwiftRepo) (string, []models.SwiftMini, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSwiftsDetailsByCountryIso2Code", ctx, countryIso2Code, swiftRepo)
+	ret := m.ctrl.Call(m, "GetSwiftsDetailsByCountryIso2Code", ctx, countryIso2Code, page, swiftRepo)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].([]models.SwiftMini)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetSwiftsDetailsByCountryIso2Code indicates an expected call of GetSwiftsDetailsByCountryIso2Code.
+func (mr *MockSwiftServiceMockRecorder) GetSwiftsDetailsByCountryIso2Code(ctx, countryIso2Code, page, swiftRepo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwiftsDetailsByCountryIso2Code", reflect.TypeOf((*MockSwiftService)(nil).GetSwiftsDetailsByCountryIso2Code), ctx, countryIso2Code, page, swiftRepo)
+}
+
+// PatchSwift mocks base method.
+func (m *MockSwiftService) PatchSwift(ctx context.Context, swiftCode string, patch []byte, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchSwift", ctx, swiftCode, patch, swiftRepo, validate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchSwift indicates an expected call of PatchSwift.
+func (mr *MockSwiftServiceMockRecorder) PatchSwift(ctx, swiftCode, patch, swiftRepo, validate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchSwift", reflect.TypeOf((*MockSwiftService)(nil).PatchSwift), ctx, swiftCode, patch, swiftRepo, validate)
+}
+
+// RestoreSwift mocks base method.
+func (m *MockSwiftService) RestoreSwift(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSwift", ctx, swiftCode, swiftRepo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreSwift indicates an expected call of RestoreSwift.
+func (mr *MockSwiftServiceMockRecorder) RestoreSwift(ctx, swiftCode, swiftRepo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSwift", reflect.TypeOf((*MockSwiftService)(nil).RestoreSwift), ctx, swiftCode, swiftRepo)
+}
+
+// Search mocks base method.
+func (m *MockSwiftService) Search(ctx context.Context, search models.SearchQuery, swiftRepo repositories.SwiftRepo) ([]models.SwiftMini, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, swiftRepo)
+	ret0, _ := ret[0].([]models.SwiftMini)
+	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// GetSwiftsDetailsByCountryIso2Code indicates an expected call of GetSwiftsDetailsByCountryIso2Code.
-func (mr *MockSwiftServiceMockRecorder) GetSwiftsDetailsByCountryIso2Code(ctx, countryIso2Code, swiftRepo any) *gomock.Call {
+// Search indicates an expected call of Search.
+func (mr *MockSwiftServiceMockRecorder) Search(ctx, search, swiftRepo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSwiftService)(nil).Search), ctx, search, swiftRepo)
+}
+
+// UpdateSwift mocks base method.
+func (m *MockSwiftService) UpdateSwift(ctx context.Context, swiftCode string, swift *models.Swift, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSwift", ctx, swiftCode, swift, swiftRepo, validate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSwift indicates an expected call of UpdateSwift.
+func (mr *MockSwiftServiceMockRecorder) UpdateSwift(ctx, swiftCode, swift, swiftRepo, validate any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwiftsDetailsByCountryIso2Code", reflect.TypeOf((*MockSwiftService)(nil).GetSwiftsDetailsByCountryIso2Code), ctx, countryIso2Code, swiftRepo)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSwift", reflect.TypeOf((*MockSwiftService)(nil).UpdateSwift), ctx, swiftCode, swift, swiftRepo, validate)
 }
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
+// apiKeyPrefix makes keys easy to recognize, e.g. by secret scanners.
+const apiKeyPrefix = "swk_"
+
+// ApiKey is a client allowed to call the API. Only a hash of the key is
+// stored, so a lost key has to be replaced.
+type ApiKey struct {
+	bun.BaseModel `bun:"table:api_keys,alias:k"`
+
+	ID        int64     `bun:"id,pk,autoincrement"`
+	Name      string    `bun:"name,notnull,unique"`
+	KeyHash   string    `bun:"key_hash,notnull,unique"`
+	Scope     string    `bun:"scope,notnull"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	RevokedAt time.Time `bun:"revoked_at,nullzero"`
+}
+
+func IsValidScope(scope string) bool {
+	return scope == ScopeRead || scope == ScopeWrite
+}
+
+// GenerateApiKey returns a new random key to hand to a client.
+func GenerateApiKey() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// HashApiKey returns the hash stored for a key. Keys are random, so a plain
+// SHA-256 is enough and keeps the lookup on every request cheap.
+func HashApiKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"fmt"
+	"github.com/uptrace/bun"
+	"strings"
+	"time"
+)
+
+// CountryVersion counts the changes to the swift codes of a country, both by
+// their country column and by the country in their code. Every response built
+// from those codes is identified by it.
+type CountryVersion struct {
+	bun.BaseModel `bun:"table:country_versions,alias:cv"`
+
+	CountryIso2 string    `bun:"country_iso2_code,pk"`
+	Version     int64     `bun:"version,notnull"`
+	UpdatedAt   time.Time `bun:"updated_at,notnull"`
+}
+
+// CountryOfSwiftCode returns the country part of a swift code, characters 5 and 6.
+func CountryOfSwiftCode(swiftCode string) string {
+	if len(swiftCode) < 6 {
+		return ""
+	}
+	return strings.ToUpper(swiftCode[4:6])
+}
+
+// ETag returns the strong entity tag of the version.
+func (version CountryVersion) ETag() string {
+	return fmt.Sprintf(`"%s-%d"`, version.CountryIso2, version.Version)
+}
+
+func (version CountryVersion) LastModified() time.Time {
+	return version.UpdatedAt
+}
+
+// MatchesIfMatch reports whether an If-Match header allows changing data of
+// this version. Weak tags never match.
+func (version CountryVersion) MatchesIfMatch(header string) bool {
+	return matchesETag(header, version.ETag(), false)
+}
+
+// MatchesIfNoneMatch reports whether an If-None-Match header names this
+// version, i.e. the client already has it.
+func (version CountryVersion) MatchesIfNoneMatch(header string) bool {
+	return matchesETag(header, version.ETag(), true)
+}
+
+// matchesETag reports whether the comma separated list of entity tags in
+// header, or "*", matches etag.
+func matchesETag(header string, etag string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if strings.HasPrefix(tag, "W/") {
+			if !weak {
+				continue
+			}
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == etag {
+			return true
+		}
+	}
+	return false
+}
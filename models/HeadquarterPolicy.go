@@ -0,0 +1,25 @@
+package models
+
+// What happens when a branch is added before its headquarter exists.
+const (
+	// MissingHeadquarterFlag adds the branch without a headquarter. It is
+	// linked once the headquarter is added.
+	MissingHeadquarterFlag   = "flag"
+	MissingHeadquarterReject = "reject"
+)
+
+// What happens to the branches of a headquarter that is deleted.
+const (
+	HeadquarterDeleteBlock   = "block"
+	HeadquarterDeleteCascade = "cascade"
+	// HeadquarterDeleteOrphan keeps the branches without a headquarter.
+	HeadquarterDeleteOrphan = "orphan"
+)
+
+func IsValidMissingHeadquarterPolicy(policy string) bool {
+	return policy == MissingHeadquarterFlag || policy == MissingHeadquarterReject
+}
+
+func IsValidHeadquarterDeletePolicy(policy string) bool {
+	return policy == HeadquarterDeleteBlock || policy == HeadquarterDeleteCascade || policy == HeadquarterDeleteOrphan
+}
@@ -0,0 +1,32 @@
+package models
+
+const (
+	SortBySwiftCode = "swiftCode"
+	SortByBankName  = "bankName"
+
+	DefaultPageLimit = 100
+	MaxPageLimit     = 1000
+)
+
+// PageRequest selects a page of a listing. After is the swift code of the
+// last item of the previous page; an empty value starts from the beginning.
+type PageRequest struct {
+	Limit  int
+	After  string
+	SortBy string
+	// HeadquarterMissing lists only the branches without a headquarter.
+	HeadquarterMissing bool
+}
+
+func IsValidSortBy(sortBy string) bool {
+	return sortBy == SortBySwiftCode || sortBy == SortByBankName
+}
+
+// SearchQuery selects a page of full-text search results. Results are ranked,
+// so they're paged by offset rather than by cursor.
+type SearchQuery struct {
+	Query   string
+	Country string
+	Limit   int
+	Offset  int
+}
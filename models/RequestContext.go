@@ -0,0 +1,52 @@
+package models
+
+import "context"
+
+// AnonymousActor is recorded as the actor of changes made without a principal in the context.
+const AnonymousActor = "anonymous"
+
+// Principal is who a request is made on behalf of.
+type Principal struct {
+	// Kind is how the principal authenticated, e.g. "apikey" or "jwt".
+	Kind  string
+	Name  string
+	Scope string
+}
+
+// Allows reports whether the principal has scope; write can also read.
+func (principal Principal) Allows(scope string) bool {
+	return principal.Scope == scope || principal.Scope == ScopeWrite
+}
+
+type contextKey int
+
+const (
+	principalKey contextKey = iota
+	requestIDKey
+)
+
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey).(Principal)
+	return principal, ok
+}
+
+// ActorFrom names the principal of ctx for the audit log.
+func ActorFrom(ctx context.Context) string {
+	if principal, ok := PrincipalFrom(ctx); ok {
+		return principal.Kind + ":" + principal.Name
+	}
+	return AnonymousActor
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+func RequestIDFrom(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/uptrace/bun"
 	"strings"
+	"time"
 )
 
 type Swift struct {
@@ -12,28 +13,61 @@ type Swift struct {
 
 	CountryIso2   string `bun:"country_iso2_code,notnull" json:"countryISO2" validate:"required,iso3166_1_alpha2"`
 	SwiftCode     string `bun:"swift_code,pk," json:"swiftCode" validate:"required,len=11,alpha,uppercase"`
+	CodeType      string `bun:"code_type,notnull,default:''" json:"codeType" validate:"omitempty,oneof=BIC8 BIC11"`
 	BankName      string `bun:"bank_name,notnull" json:"bankName" validate:"required"`
 	Address       string `bun:"address,notnull" json:"address" validate:"required"`
+	TownName      string `bun:"town_name,notnull,default:''" json:"townName"`
 	CountryName   string `bun:"country_name,notnull" json:"countryName" validate:"required"`
+	TimeZone      string `bun:"time_zone,notnull,default:''" json:"timeZone" validate:"omitempty,timezone"`
 	IsHeadquarter bool   `bun:"is_headquarter,notnull" json:"isHeadquarter" validate:"boolean"`
+	// HeadquarterCode links a branch to its headquarter. It is set by the
+	// service, never by clients, and is empty for headquarters and orphans.
+	HeadquarterCode string `bun:"headquarter_code,nullzero" json:"-"`
+	// HeadquarterMissing flags a branch without a headquarter. It is derived
+	// from HeadquarterCode on every write.
+	HeadquarterMissing bool `bun:"headquarter_missing,notnull,default:false" json:"headquarterMissing,omitempty"`
+	// DeletedAt is set when the swift code is deleted. Deleted codes are
+	// hidden from every read until they are restored or purged.
+	DeletedAt time.Time `bun:"deleted_at,soft_delete,nullzero" json:"-"`
+	// UpdatedAt is set by the database whenever the row is written.
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"-"`
 }
 
 func (s *Swift) BeforeAppendModel(ctx context.Context, query bun.Query) error {
 	s.SwiftCode = strings.ToUpper(s.SwiftCode)
 	s.CountryIso2 = strings.ToUpper(s.CountryIso2)
 	s.CountryName = strings.ToUpper(s.CountryName)
+	s.FlagHeadquarterMissing()
 	return nil
 }
 
+// FlagHeadquarterMissing sets HeadquarterMissing from HeadquarterCode, like
+// the swifts_flag_headquarter_missing trigger.
+func (s *Swift) FlagHeadquarterMissing() {
+	s.HeadquarterMissing = s.HeadquarterCode == "" && !IsSwiftCodeOfHeadquarter(s.SwiftCode)
+}
+
 func IsSwiftCodeOfHeadquarter(swiftCode string) bool {
 	return strings.HasSuffix(strings.ToUpper(swiftCode), "XXX")
 }
 
+// HeadquarterCodeOf returns the code of the headquarter a branch belongs to.
+func HeadquarterCodeOf(swiftCode string) string {
+	if len(swiftCode) < 8 {
+		return ""
+	}
+	return strings.ToUpper(swiftCode[:8]) + "XXX"
+}
+
+// HeadquarterConsistencyRule is reported on isHeadquarter when it disagrees
+// with the XXX suffix of the swift code.
+const HeadquarterConsistencyRule = "swiftCode_isHeadquarter_inconsistency"
+
 func SwiftStructLevelValidation(sl validator.StructLevel) {
 	swift := sl.Current().Interface().(Swift)
 
 	if IsSwiftCodeOfHeadquarter(swift.SwiftCode) != swift.IsHeadquarter {
 		sl.ReportError(swift.IsHeadquarter, "isHeadquarter", "IsHeadquarter",
-			"swiftCode_isHeadquarter_inconsistency", "")
+			HeadquarterConsistencyRule, "")
 	}
 }
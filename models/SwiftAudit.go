@@ -0,0 +1,29 @@
+package models
+
+import (
+	"encoding/json"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+const (
+	AuditActionAdd     = "add"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+)
+
+// SwiftAudit is one entry of the append-only log of changes to a swift code.
+// Before and After are JSON snapshots of the swift, null when it didn't exist.
+type SwiftAudit struct {
+	bun.BaseModel `bun:"table:swift_audit,alias:a"`
+
+	ID        int64           `bun:"id,pk,autoincrement" json:"id"`
+	SwiftCode string          `bun:"swift_code,notnull" json:"swiftCode"`
+	Action    string          `bun:"action,notnull" json:"action"`
+	Actor     string          `bun:"actor,notnull" json:"actor"`
+	RequestID string          `bun:"request_id,notnull" json:"requestId"`
+	Before    json.RawMessage `bun:"before,type:jsonb,nullzero" json:"before"`
+	After     json.RawMessage `bun:"after,type:jsonb,nullzero" json:"after"`
+	CreatedAt time.Time       `bun:"created_at,notnull" json:"createdAt"`
+}
@@ -0,0 +1,27 @@
+package models
+
+// CSVHeader is the column layout of the SWIFT directory file (data.csv).
+var CSVHeader = []string{
+	"COUNTRY ISO2 CODE",
+	"SWIFT CODE",
+	"CODE TYPE",
+	"NAME",
+	"ADDRESS",
+	"TOWN NAME",
+	"COUNTRY NAME",
+	"TIME ZONE",
+}
+
+// CSVRecord returns the swift as a row matching CSVHeader.
+func (s *Swift) CSVRecord() []string {
+	return []string{
+		s.CountryIso2,
+		s.SwiftCode,
+		s.CodeType,
+		s.BankName,
+		s.Address,
+		s.TownName,
+		s.CountryName,
+		s.TimeZone,
+	}
+}
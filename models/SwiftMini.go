@@ -3,7 +3,19 @@ package models
 type SwiftMini struct {
 	CountryIso2   string `bun:"country_iso2_code" json:"countryISO2"`
 	SwiftCode     string `bun:"swift_code," json:"swiftCode"`
+	CodeType      string `bun:"code_type" json:"codeType"`
 	BankName      string `bun:"bank_name" json:"bankName"`
 	Address       string `bun:"address" json:"address"`
+	TownName      string `bun:"town_name" json:"townName"`
+	TimeZone      string `bun:"time_zone" json:"timeZone"`
 	IsHeadquarter bool   `bun:"is_headquarter" json:"isHeadquarter"`
+	// HeadquarterMissing is only read by the country listing and search.
+	HeadquarterMissing bool `bun:"headquarter_missing" json:"headquarterMissing,omitempty"`
+}
+
+// BranchParent is the headquarter of a branch (nil when it doesn't exist) and
+// how many other branches share its 8-character bank prefix.
+type BranchParent struct {
+	Headquarter        *SwiftMini
+	SiblingBranchCount int
 }
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// SwiftVersion dates the last change to the codes of a swift code's bank, the
+// codes sharing its first 8 characters. They include its headquarter and
+// branches, so it changes whenever the response for the code can.
+type SwiftVersion struct {
+	SwiftCode string
+	UpdatedAt time.Time
+}
+
+// ETag returns the strong entity tag of the version.
+func (version SwiftVersion) ETag() string {
+	return fmt.Sprintf(`"%s-%d"`, version.SwiftCode, version.UpdatedAt.UnixNano())
+}
+
+func (version SwiftVersion) LastModified() time.Time {
+	return version.UpdatedAt
+}
+
+// MatchesIfMatch reports whether an If-Match header allows changing the swift
+// code at this version. Weak tags never match.
+func (version SwiftVersion) MatchesIfMatch(header string) bool {
+	return matchesETag(header, version.ETag(), false)
+}
+
+// MatchesIfNoneMatch reports whether an If-None-Match header names this
+// version, i.e. the client already has it.
+func (version SwiftVersion) MatchesIfNoneMatch(header string) bool {
+	return matchesETag(header, version.ETag(), true)
+}
@@ -152,6 +152,47 @@ func TestSwiftStructLevelValidation(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "Valid code type and time zone",
+			swift: Swift{
+				CountryIso2:   "AL",
+				SwiftCode:     "AAISALTRXXX",
+				CodeType:      "BIC11",
+				BankName:      "United Bank of Albania",
+				Address:       "Hyrja 3",
+				TownName:      "TIRANA",
+				CountryName:   "Albania",
+				TimeZone:      "Europe/Tirane",
+				IsHeadquarter: true,
+			},
+			expectError: false,
+		},
+		{
+			name: "Invalid code type",
+			swift: Swift{
+				CountryIso2:   "AL",
+				SwiftCode:     "AAISALTRXXX",
+				CodeType:      "BIC9",
+				BankName:      "United Bank of Albania",
+				Address:       "Hyrja 3",
+				CountryName:   "Albania",
+				IsHeadquarter: true,
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid time zone - not an IANA zone name",
+			swift: Swift{
+				CountryIso2:   "AL",
+				SwiftCode:     "AAISALTRXXX",
+				BankName:      "United Bank of Albania",
+				Address:       "Hyrja 3",
+				CountryName:   "Albania",
+				TimeZone:      "Europe/Atlantis",
+				IsHeadquarter: true,
+			},
+			expectError: true,
+		},
 		{
 			name: "Missing required fields",
 			swift: Swift{
@@ -180,11 +221,12 @@ func TestSwiftStructLevelValidation(t *testing.T) {
 
 func TestSwift_BeforeAppendModel(t *testing.T) {
 	tests := []struct {
-		name         string
-		swift        Swift
-		expectedCode string
-		expectedIso2 string
-		expectedName string
+		name                       string
+		swift                      Swift
+		expectedCode               string
+		expectedIso2               string
+		expectedName               string
+		expectedHeadquarterMissing bool
 	}{
 		{
 			name: "Convert to uppercase",
@@ -208,6 +250,31 @@ func TestSwift_BeforeAppendModel(t *testing.T) {
 			expectedIso2: "US",
 			expectedName: "UNITED STATES",
 		},
+		{
+			name: "Branch without headquarter",
+			swift: Swift{
+				SwiftCode:   "ABCDEF12001",
+				CountryIso2: "US",
+				CountryName: "UNITED STATES",
+			},
+			expectedCode:               "ABCDEF12001",
+			expectedIso2:               "US",
+			expectedName:               "UNITED STATES",
+			expectedHeadquarterMissing: true,
+		},
+		{
+			name: "Branch with headquarter",
+			swift: Swift{
+				SwiftCode:          "ABCDEF12001",
+				CountryIso2:        "US",
+				CountryName:        "UNITED STATES",
+				HeadquarterCode:    "ABCDEF12XXX",
+				HeadquarterMissing: true,
+			},
+			expectedCode: "ABCDEF12001",
+			expectedIso2: "US",
+			expectedName: "UNITED STATES",
+		},
 	}
 
 	for _, tt := range tests {
@@ -217,6 +284,7 @@ func TestSwift_BeforeAppendModel(t *testing.T) {
 			assert.Equal(t, tt.expectedCode, tt.swift.SwiftCode)
 			assert.Equal(t, tt.expectedIso2, tt.swift.CountryIso2)
 			assert.Equal(t, tt.expectedName, tt.swift.CountryName)
+			assert.Equal(t, tt.expectedHeadquarterMissing, tt.swift.HeadquarterMissing)
 		})
 	}
 }
@@ -1,5 +1,26 @@
 package models
 
+import (
+	"github.com/go-playground/validator/v10"
+	"reflect"
+	"strings"
+)
+
 type SwiftValidator interface {
 	Struct(s interface{}) error
 }
+
+// NewSwiftValidator returns a validator for Swift that reports fields by
+// their JSON names, so validation errors match the request body.
+func NewSwiftValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	validate.RegisterStructValidation(SwiftStructLevelValidation, Swift{})
+	return validate
+}
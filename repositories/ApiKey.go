@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"awesomeProject/models"
+	"context"
+)
+
+type ApiKeyRepo interface {
+	// GetByHash returns the key with the given hash, or sql.ErrNoRows if there
+	// is none or it was revoked.
+	GetByHash(ctx context.Context, keyHash string) (*models.ApiKey, error)
+	GetAll(ctx context.Context) ([]models.ApiKey, error)
+	AddApiKey(ctx context.Context, apiKey *models.ApiKey) error
+	// RevokeApiKey returns sql.ErrNoRows if there is no active key with the name.
+	RevokeApiKey(ctx context.Context, name string) error
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"awesomeProject/dbs"
+	"awesomeProject/models"
+	"context"
+	"database/sql"
+)
+
+type ApiKeyRepoPostgres struct {
+	Db dbs.SwiftDb
+}
+
+func (apiKeyRepo ApiKeyRepoPostgres) GetByHash(ctx context.Context, keyHash string) (*models.ApiKey, error) {
+	apiKey := &models.ApiKey{}
+	err := apiKeyRepo.Db.NewSelect().Model(apiKey).
+		Where("key_hash = ?", keyHash).
+		Where("revoked_at IS NULL").
+		Scan(ctx)
+	return apiKey, err
+}
+
+func (apiKeyRepo ApiKeyRepoPostgres) GetAll(ctx context.Context) ([]models.ApiKey, error) {
+	apiKeys := make([]models.ApiKey, 0)
+	err := apiKeyRepo.Db.NewSelect().Model(&apiKeys).Order("name").Scan(ctx)
+
+	return apiKeys, err
+}
+
+func (apiKeyRepo ApiKeyRepoPostgres) AddApiKey(ctx context.Context, apiKey *models.ApiKey) error {
+	_, err := apiKeyRepo.Db.NewInsert().Model(apiKey).Exec(ctx)
+
+	return err
+}
+
+func (apiKeyRepo ApiKeyRepoPostgres) RevokeApiKey(ctx context.Context, name string) error {
+	res, err := apiKeyRepo.Db.NewUpdate().
+		Model((*models.ApiKey)(nil)).
+		Set("revoked_at = current_timestamp").
+		Where("name = ?", name).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
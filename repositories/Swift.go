@@ -3,13 +3,52 @@ package repositories
 import (
 	"awesomeProject/models"
 	"context"
+	"time"
 )
 
 type SwiftRepo interface {
 	GetBySwiftCode(context.Context, string) (*models.Swift, error)
 	GetBranchesBySwiftCode(context.Context, string) ([]models.SwiftMini, error)
-	GetByCountryIso2Code(context.Context, string) ([]models.SwiftMini, error)
+	GetBranchParent(ctx context.Context, branchCode string) (*models.BranchParent, error)
+	// GetByCountryIso2Code returns a page of a country's swifts. It returns
+	// sql.ErrNoRows when the country has none, or when page.After is not a
+	// swift code of the country, deleted ones included.
+	GetByCountryIso2Code(context.Context, string, models.PageRequest) ([]models.SwiftMini, error)
 	GetCountryNameByIso2Code(context.Context, string) (string, error)
+	Search(context.Context, models.SearchQuery) ([]models.SwiftMini, error)
+	// ForEachSwift calls fn for every swift of a country (all countries when
+	// empty) in swift code order, without loading them all into memory.
+	ForEachSwift(ctx context.Context, countryIso2Code string, fn func(*models.Swift) error) error
 	AddSwift(context.Context, *models.Swift) error
+	UpdateSwift(context.Context, *models.Swift) error
+	// DeleteSwift soft deletes a swift code, hiding it from every other method
+	// until it is restored or purged.
 	DeleteSwift(context.Context, string) error
+	// RestoreSwift undeletes a swift code, or returns sql.ErrNoRows if there
+	// is no deleted swift code to restore.
+	RestoreSwift(ctx context.Context, swiftCode string) error
+	// PurgeDeleted permanently removes the swift codes deleted before deletedBefore.
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error)
+	// LinkBranches sets the headquarter of every branch sharing its bank
+	// prefix. It does nothing if the headquarter does not exist.
+	LinkBranches(ctx context.Context, headquarterCode string) error
+	// UnlinkBranches leaves the branches of a headquarter without one.
+	UnlinkBranches(ctx context.Context, headquarterCode string) error
+	DeleteBranches(ctx context.Context, headquarterCode string) error
+	// AddAudit appends an entry to the audit log; entries are never changed.
+	AddAudit(ctx context.Context, audit *models.SwiftAudit) error
+	// GetAuditBySwiftCode returns the audit log of a swift code, oldest first.
+	GetAuditBySwiftCode(ctx context.Context, swiftCode string) ([]models.SwiftAudit, error)
+	// GetCountryVersion returns the version of a country's swift codes, or
+	// version 0 if they never changed.
+	GetCountryVersion(ctx context.Context, countryIso2Code string) (models.CountryVersion, error)
+	// GetSwiftVersion returns when the codes of a swift code's bank, deleted
+	// ones included, last changed, or a zero time if there are none.
+	GetSwiftVersion(ctx context.Context, swiftCode string) (models.SwiftVersion, error)
+	// LockCountryVersion is GetCountryVersion, but inside RunInTx it also keeps
+	// the version from changing until the transaction ends.
+	LockCountryVersion(ctx context.Context, countryIso2Code string) (models.CountryVersion, error)
+	// RunInTx runs fn in a transaction. Only the SwiftRepo passed to fn takes
+	// part in it; an error returned by fn rolls everything back.
+	RunInTx(ctx context.Context, fn func(ctx context.Context, swiftRepo SwiftRepo) error) error
 }
@@ -0,0 +1,239 @@
+package repositories
+
+import (
+	"awesomeProject/cache"
+	"awesomeProject/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	swiftCacheKey    = "swift:"
+	branchesCacheKey = "branches:"
+	countryCacheKey  = "country:"
+)
+
+// SwiftRepoCached answers GetBySwiftCode, GetBranchesBySwiftCode,
+// GetByCountryIso2Code and the versions of swift codes and countries from a
+// cache in front of another SwiftRepo. Writes made through it invalidate what
+// they change once committed; writes made elsewhere, e.g. by the importer,
+// show up when the entries expire.
+type SwiftRepoCached struct {
+	swiftRepoWrites
+
+	cache cache.Cache
+	ttl   time.Duration
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+func NewSwiftRepoCached(swiftRepo SwiftRepo, swiftCache cache.Cache, ttl time.Duration) *SwiftRepoCached {
+	cached := &SwiftRepoCached{
+		cache: swiftCache,
+		ttl:   ttl,
+	}
+	cached.swiftRepoWrites = swiftRepoWrites{SwiftRepo: swiftRepo, changed: cached.invalidate}
+	return cached
+}
+
+// Stats returns the hits and misses of the cached lookups so far.
+func (swiftRepo *SwiftRepoCached) Stats() cache.Stats {
+	return cache.Stats{
+		Hits:   swiftRepo.hits.Load(),
+		Misses: swiftRepo.misses.Load(),
+	}
+}
+
+// cachedSwift keeps the fields models.Swift leaves out of its JSON.
+type cachedSwift struct {
+	models.Swift
+	HeadquarterCode string `json:"headquarterCode"`
+}
+
+func (swiftRepo *SwiftRepoCached) GetBySwiftCode(ctx context.Context, swiftCode string) (*models.Swift, error) {
+	entry, err := readThrough(ctx, swiftRepo, swiftCacheKey+swiftCode, func() (cachedSwift, error) {
+		swift, err := swiftRepo.SwiftRepo.GetBySwiftCode(ctx, swiftCode)
+		if err != nil {
+			return cachedSwift{}, err
+		}
+		return cachedSwift{Swift: *swift, HeadquarterCode: swift.HeadquarterCode}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Swift.HeadquarterCode = entry.HeadquarterCode
+	return &entry.Swift, nil
+}
+
+func (swiftRepo *SwiftRepoCached) GetBranchesBySwiftCode(ctx context.Context, swiftCode string) ([]models.SwiftMini, error) {
+	return readThrough(ctx, swiftRepo, branchesCacheKey+swiftCode, func() ([]models.SwiftMini, error) {
+		return swiftRepo.SwiftRepo.GetBranchesBySwiftCode(ctx, swiftCode)
+	})
+}
+
+func (swiftRepo *SwiftRepoCached) GetByCountryIso2Code(ctx context.Context, countryIso2Code string, page models.PageRequest) ([]models.SwiftMini, error) {
+	key := fmt.Sprintf("%s%s:%s:%s:%d:%t", countryCacheKey, countryIso2Code, page.SortBy, page.After, page.Limit, page.HeadquarterMissing)
+	return readThrough(ctx, swiftRepo, key, func() ([]models.SwiftMini, error) {
+		return swiftRepo.SwiftRepo.GetByCountryIso2Code(ctx, countryIso2Code, page)
+	})
+}
+
+// GetCountryVersion is cached like the country pages the version names, so
+// conditional requests don't query the database on a warm cache either.
+func (swiftRepo *SwiftRepoCached) GetCountryVersion(ctx context.Context, countryIso2Code string) (models.CountryVersion, error) {
+	key := countryCacheKey + strings.ToUpper(countryIso2Code) + ":version"
+	return readThrough(ctx, swiftRepo, key, func() (models.CountryVersion, error) {
+		return swiftRepo.SwiftRepo.GetCountryVersion(ctx, countryIso2Code)
+	})
+}
+
+func (swiftRepo *SwiftRepoCached) GetSwiftVersion(ctx context.Context, swiftCode string) (models.SwiftVersion, error) {
+	key := swiftCacheKey + strings.ToUpper(swiftCode) + ":version"
+	return readThrough(ctx, swiftRepo, key, func() (models.SwiftVersion, error) {
+		return swiftRepo.SwiftRepo.GetSwiftVersion(ctx, swiftCode)
+	})
+}
+
+// readThrough returns the cached value of key, or loads and caches it. Errors
+// are not cached, and a failing cache only makes every lookup a miss.
+func readThrough[T any](ctx context.Context, swiftRepo *SwiftRepoCached, key string, load func() (T, error)) (T, error) {
+	var value T
+	encoded, ok, err := swiftRepo.cache.Get(ctx, key)
+	if err == nil && ok && json.Unmarshal(encoded, &value) == nil {
+		swiftRepo.hits.Add(1)
+		return value, nil
+	}
+	swiftRepo.misses.Add(1)
+
+	value, err = load()
+	if err != nil {
+		return value, err
+	}
+
+	encoded, err = json.Marshal(value)
+	if err == nil {
+		_ = swiftRepo.cache.Set(ctx, key, encoded, swiftRepo.ttl)
+	}
+	return value, nil
+}
+
+// invalidate drops the entries a write to the codes sharing each bank prefix
+// may have changed: the codes themselves, their headquarter's branches and,
+// since codes can move between pages, every country page.
+func (swiftRepo *SwiftRepoCached) invalidate(ctx context.Context, bankPrefixes ...string) {
+	if len(bankPrefixes) == 0 {
+		return
+	}
+
+	prefixes := []string{countryCacheKey}
+	for _, bankPrefix := range bankPrefixes {
+		prefixes = append(prefixes, swiftCacheKey+bankPrefix, branchesCacheKey+bankPrefix)
+	}
+	_ = swiftRepo.cache.DeletePrefix(ctx, prefixes...)
+}
+
+// bankPrefixOf returns the first 8 characters of a swift code, shared by a
+// headquarter and its branches.
+func bankPrefixOf(swiftCode string) string {
+	swiftCode = strings.ToUpper(swiftCode)
+	if len(swiftCode) < 8 {
+		return swiftCode
+	}
+	return swiftCode[:8]
+}
+
+// swiftRepoWrites reports the bank prefixes changed by the writes to SwiftRepo.
+// Inside RunInTx they are only reported once the transaction commits.
+type swiftRepoWrites struct {
+	SwiftRepo
+	changed func(ctx context.Context, bankPrefixes ...string)
+}
+
+func (swiftRepo swiftRepoWrites) AddSwift(ctx context.Context, swift *models.Swift) error {
+	err := swiftRepo.SwiftRepo.AddSwift(ctx, swift)
+	if err == nil {
+		swiftRepo.changed(ctx, bankPrefixOf(swift.SwiftCode))
+	}
+	return err
+}
+
+func (swiftRepo swiftRepoWrites) UpdateSwift(ctx context.Context, swift *models.Swift) error {
+	err := swiftRepo.SwiftRepo.UpdateSwift(ctx, swift)
+	if err == nil {
+		swiftRepo.changed(ctx, bankPrefixOf(swift.SwiftCode))
+	}
+	return err
+}
+
+func (swiftRepo swiftRepoWrites) DeleteSwift(ctx context.Context, swiftCode string) error {
+	err := swiftRepo.SwiftRepo.DeleteSwift(ctx, swiftCode)
+	if err == nil {
+		swiftRepo.changed(ctx, bankPrefixOf(swiftCode))
+	}
+	return err
+}
+
+func (swiftRepo swiftRepoWrites) RestoreSwift(ctx context.Context, swiftCode string) error {
+	err := swiftRepo.SwiftRepo.RestoreSwift(ctx, swiftCode)
+	if err == nil {
+		swiftRepo.changed(ctx, bankPrefixOf(swiftCode))
+	}
+	return err
+}
+
+// PurgeDeleted can unlink branches of any bank, so it drops every entry.
+func (swiftRepo swiftRepoWrites) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error) {
+	purged, err := swiftRepo.SwiftRepo.PurgeDeleted(ctx, deletedBefore)
+	if err == nil && purged > 0 {
+		swiftRepo.changed(ctx, "")
+	}
+	return purged, err
+}
+
+func (swiftRepo swiftRepoWrites) LinkBranches(ctx context.Context, headquarterCode string) error {
+	err := swiftRepo.SwiftRepo.LinkBranches(ctx, headquarterCode)
+	if err == nil {
+		swiftRepo.changed(ctx, bankPrefixOf(headquarterCode))
+	}
+	return err
+}
+
+func (swiftRepo swiftRepoWrites) UnlinkBranches(ctx context.Context, headquarterCode string) error {
+	err := swiftRepo.SwiftRepo.UnlinkBranches(ctx, headquarterCode)
+	if err == nil {
+		swiftRepo.changed(ctx, bankPrefixOf(headquarterCode))
+	}
+	return err
+}
+
+func (swiftRepo swiftRepoWrites) DeleteBranches(ctx context.Context, headquarterCode string) error {
+	err := swiftRepo.SwiftRepo.DeleteBranches(ctx, headquarterCode)
+	if err == nil {
+		swiftRepo.changed(ctx, bankPrefixOf(headquarterCode))
+	}
+	return err
+}
+
+// RunInTx hands fn an uncached repo, so it reads its own writes, and reports
+// the changes of fn only if the transaction commits.
+func (swiftRepo swiftRepoWrites) RunInTx(ctx context.Context, fn func(ctx context.Context, swiftRepo SwiftRepo) error) error {
+	var changed []string
+	err := swiftRepo.SwiftRepo.RunInTx(ctx, func(ctx context.Context, txRepo SwiftRepo) error {
+		return fn(ctx, swiftRepoWrites{
+			SwiftRepo: txRepo,
+			changed: func(_ context.Context, bankPrefixes ...string) {
+				changed = append(changed, bankPrefixes...)
+			},
+		})
+	})
+	if err == nil {
+		swiftRepo.changed(ctx, changed...)
+	}
+	return err
+}
@@ -0,0 +1,172 @@
+package repositories
+
+import (
+	"awesomeProject/cache"
+	"awesomeProject/models"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSwiftRepoCached_GetBySwiftCode(t *testing.T) {
+	memoryRepo := newSeededSwiftRepoMemory(t)
+	repo := NewSwiftRepoCached(memoryRepo, cache.NewLRU(100), time.Minute)
+	ctx := context.Background()
+
+	swift, err := repo.GetBySwiftCode(ctx, "ABCDPLPW001")
+	assert.NoError(t, err)
+	assert.Equal(t, "Krakow", swift.Address)
+	assert.Equal(t, cache.Stats{Hits: 0, Misses: 1}, repo.Stats())
+
+	// Changes made around the cache are not seen until the entry is invalidated.
+	assert.NoError(t, memoryRepo.UpdateSwift(ctx, &models.Swift{CountryIso2: "PL", SwiftCode: "ABCDPLPW001", BankName: "Bank A", Address: "Lodz", CountryName: "POLAND"}))
+
+	swift, err = repo.GetBySwiftCode(ctx, "ABCDPLPW001")
+	assert.NoError(t, err)
+	assert.Equal(t, "Krakow", swift.Address)
+	assert.Equal(t, "ABCDPLPWXXX", swift.HeadquarterCode)
+	assert.Equal(t, cache.Stats{Hits: 1, Misses: 1}, repo.Stats())
+
+	_, err = repo.GetBySwiftCode(ctx, "NOPENOPEXXX")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = repo.GetBySwiftCode(ctx, "NOPENOPEXXX")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Equal(t, cache.Stats{Hits: 1, Misses: 3}, repo.Stats())
+}
+
+func TestSwiftRepoCached_Invalidation(t *testing.T) {
+	ctx := context.Background()
+	page := models.PageRequest{Limit: 10, SortBy: models.SortBySwiftCode}
+
+	tests := []struct {
+		name  string
+		write func(repo SwiftRepo) error
+		check func(t *testing.T, repo SwiftRepo)
+	}{
+		{
+			name: "AddSwift",
+			write: func(repo SwiftRepo) error {
+				return repo.AddSwift(ctx, &models.Swift{CountryIso2: "PL", SwiftCode: "ABCDPLPW003", BankName: "Bank A", Address: "Poznan", CountryName: "POLAND", HeadquarterCode: "ABCDPLPWXXX"})
+			},
+			check: func(t *testing.T, repo SwiftRepo) {
+				branches, err := repo.GetBranchesBySwiftCode(ctx, "ABCDPLPWXXX")
+				assert.NoError(t, err)
+				assert.Len(t, branches, 3)
+
+				swifts, err := repo.GetByCountryIso2Code(ctx, "PL", page)
+				assert.NoError(t, err)
+				assert.Len(t, swifts, 4)
+			},
+		},
+		{
+			name: "DeleteSwift",
+			write: func(repo SwiftRepo) error {
+				return repo.DeleteSwift(ctx, "ABCDPLPW001")
+			},
+			check: func(t *testing.T, repo SwiftRepo) {
+				_, err := repo.GetBySwiftCode(ctx, "ABCDPLPW001")
+				assert.ErrorIs(t, err, sql.ErrNoRows)
+
+				branches, err := repo.GetBranchesBySwiftCode(ctx, "ABCDPLPWXXX")
+				assert.NoError(t, err)
+				assert.Len(t, branches, 1)
+
+				swifts, err := repo.GetByCountryIso2Code(ctx, "PL", page)
+				assert.NoError(t, err)
+				assert.Len(t, swifts, 2)
+			},
+		},
+		{
+			name: "UnlinkBranches",
+			write: func(repo SwiftRepo) error {
+				return repo.UnlinkBranches(ctx, "ABCDPLPWXXX")
+			},
+			check: func(t *testing.T, repo SwiftRepo) {
+				swift, err := repo.GetBySwiftCode(ctx, "ABCDPLPW001")
+				assert.NoError(t, err)
+				assert.Empty(t, swift.HeadquarterCode)
+
+				branches, err := repo.GetBranchesBySwiftCode(ctx, "ABCDPLPWXXX")
+				assert.NoError(t, err)
+				assert.Empty(t, branches)
+			},
+		},
+		{
+			name: "Committed transaction",
+			write: func(repo SwiftRepo) error {
+				return repo.RunInTx(ctx, func(ctx context.Context, txRepo SwiftRepo) error {
+					return txRepo.DeleteSwift(ctx, "ABCDPLPW001")
+				})
+			},
+			check: func(t *testing.T, repo SwiftRepo) {
+				_, err := repo.GetBySwiftCode(ctx, "ABCDPLPW001")
+				assert.ErrorIs(t, err, sql.ErrNoRows)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewSwiftRepoCached(newSeededSwiftRepoMemory(t), cache.NewLRU(100), time.Minute)
+
+			_, err := repo.GetBySwiftCode(ctx, "ABCDPLPW001")
+			assert.NoError(t, err)
+			_, err = repo.GetBranchesBySwiftCode(ctx, "ABCDPLPWXXX")
+			assert.NoError(t, err)
+			_, err = repo.GetByCountryIso2Code(ctx, "PL", page)
+			assert.NoError(t, err)
+
+			assert.NoError(t, tt.write(repo))
+			tt.check(t, repo)
+			assert.Equal(t, uint64(0), repo.Stats().Hits)
+		})
+	}
+}
+
+func TestSwiftRepoCached_RolledBackTransaction(t *testing.T) {
+	swiftCache := cache.NewLRU(100)
+	repo := NewSwiftRepoCached(newSeededSwiftRepoMemory(t), swiftCache, time.Minute)
+	ctx := context.Background()
+
+	_, err := repo.GetBySwiftCode(ctx, "EFGHDEFFXXX")
+	assert.NoError(t, err)
+
+	err = repo.RunInTx(ctx, func(ctx context.Context, txRepo SwiftRepo) error {
+		assert.NoError(t, txRepo.DeleteSwift(ctx, "EFGHDEFFXXX"))
+
+		_, err := txRepo.GetBySwiftCode(ctx, "EFGHDEFFXXX")
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		return errors.New("rollback")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, swiftCache.Len())
+
+	swift, err := repo.GetBySwiftCode(ctx, "EFGHDEFFXXX")
+	assert.NoError(t, err)
+	assert.Equal(t, "Berlin", swift.Address)
+	assert.Equal(t, cache.Stats{Hits: 1, Misses: 1}, repo.Stats())
+}
+
+func TestSwiftRepoCached_GetCountryVersion(t *testing.T) {
+	repo := NewSwiftRepoCached(newSeededSwiftRepoMemory(t), cache.NewLRU(100), time.Minute)
+	ctx := context.Background()
+
+	version, err := repo.GetCountryVersion(ctx, "PL")
+	assert.NoError(t, err)
+
+	cached, err := repo.GetCountryVersion(ctx, "pl")
+	assert.NoError(t, err)
+	assert.Equal(t, version.ETag(), cached.ETag())
+	assert.True(t, version.UpdatedAt.Equal(cached.UpdatedAt))
+	assert.Equal(t, cache.Stats{Hits: 1, Misses: 1}, repo.Stats())
+
+	assert.NoError(t, repo.DeleteSwift(ctx, "ABCDPLPW001"))
+
+	changed, err := repo.GetCountryVersion(ctx, "PL")
+	assert.NoError(t, err)
+	assert.Greater(t, changed.Version, version.Version)
+	assert.Equal(t, cache.Stats{Hits: 1, Misses: 2}, repo.Stats())
+}
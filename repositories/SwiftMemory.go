@@ -0,0 +1,697 @@
+package repositories
+
+import (
+	"awesomeProject/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SwiftRepoMemory is an in-memory SwiftRepo. It keeps the same indexes as the
+// Postgres schema (full code, 8-character bank prefix and country) so lookups
+// don't need to scan every record.
+type SwiftRepoMemory struct {
+	// txMu serializes transactions with each other and with writes made
+	// outside them, so rolling a transaction back never undoes other writes.
+	txMu      sync.Mutex
+	mu        sync.RWMutex
+	byCode    map[string]models.Swift
+	byPrefix  map[string]map[string]struct{}
+	byCountry map[string]map[string]struct{}
+	// deleted holds soft deleted swifts, which are kept out of the indexes.
+	deleted  map[string]models.Swift
+	audits   []models.SwiftAudit
+	versions map[string]models.CountryVersion
+}
+
+func NewSwiftRepoMemory() *SwiftRepoMemory {
+	return &SwiftRepoMemory{
+		byCode:    make(map[string]models.Swift),
+		byPrefix:  make(map[string]map[string]struct{}),
+		byCountry: make(map[string]map[string]struct{}),
+		deleted:   make(map[string]models.Swift),
+		versions:  make(map[string]models.CountryVersion),
+	}
+}
+
+// touch stamps a written swift and bumps the versions of the countries it is
+// and was in, like the triggers of the Postgres schema. The caller must hold mu.
+func (swiftRepo *SwiftRepoMemory) touch(swift *models.Swift, previousCountry string) {
+	now := time.Now()
+	swift.UpdatedAt = now
+	swift.FlagHeadquarterMissing()
+
+	countries := []string{swift.CountryIso2, models.CountryOfSwiftCode(swift.SwiftCode), previousCountry}
+	for i, country := range countries {
+		if country == "" || slices.Contains(countries[:i], country) {
+			continue
+		}
+		version := swiftRepo.versions[country]
+		swiftRepo.versions[country] = models.CountryVersion{CountryIso2: country, Version: version.Version + 1, UpdatedAt: now}
+	}
+}
+
+func swiftCodePrefix(swiftCode string) string {
+	if len(swiftCode) < 8 {
+		return swiftCode
+	}
+	return swiftCode[:8]
+}
+
+func toSwiftMini(swift models.Swift) models.SwiftMini {
+	return models.SwiftMini{
+		CountryIso2:        swift.CountryIso2,
+		SwiftCode:          swift.SwiftCode,
+		CodeType:           swift.CodeType,
+		BankName:           swift.BankName,
+		Address:            swift.Address,
+		TownName:           swift.TownName,
+		TimeZone:           swift.TimeZone,
+		IsHeadquarter:      swift.IsHeadquarter,
+		HeadquarterMissing: swift.HeadquarterMissing,
+	}
+}
+
+func addToIndex(index map[string]map[string]struct{}, key string, swiftCode string) {
+	codes, ok := index[key]
+	if !ok {
+		codes = make(map[string]struct{})
+		index[key] = codes
+	}
+	codes[swiftCode] = struct{}{}
+}
+
+func removeFromIndex(index map[string]map[string]struct{}, key string, swiftCode string) {
+	codes, ok := index[key]
+	if !ok {
+		return
+	}
+	delete(codes, swiftCode)
+	if len(codes) == 0 {
+		delete(index, key)
+	}
+}
+
+// sortedCodes returns the codes of an index entry in a stable order.
+func sortedCodes(codes map[string]struct{}) []string {
+	sorted := make([]string, 0, len(codes))
+	for code := range codes {
+		sorted = append(sorted, code)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func (swiftRepo *SwiftRepoMemory) GetBySwiftCode(_ context.Context, swiftCode string) (*models.Swift, error) {
+	swiftRepo.mu.RLock()
+	defer swiftRepo.mu.RUnlock()
+
+	swift, ok := swiftRepo.byCode[swiftCode]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &swift, nil
+}
+
+func (swiftRepo *SwiftRepoMemory) GetBranchesBySwiftCode(_ context.Context, swiftCode string) ([]models.SwiftMini, error) {
+	if len(swiftCode) != 11 {
+		return nil, fmt.Errorf("swiftCode must be 11 characters")
+	}
+
+	swiftRepo.mu.RLock()
+	defer swiftRepo.mu.RUnlock()
+
+	branches := make([]models.SwiftMini, 0)
+	for _, code := range sortedCodes(swiftRepo.byPrefix[swiftCode[:8]]) {
+		swift := swiftRepo.byCode[code]
+		if swift.HeadquarterCode != swiftCode {
+			continue
+		}
+		branches = append(branches, toSwiftMini(swift))
+	}
+
+	return branches, nil
+}
+
+func (swiftRepo *SwiftRepoMemory) GetBranchParent(_ context.Context, branchCode string) (*models.BranchParent, error) {
+	if len(branchCode) != 11 {
+		return nil, fmt.Errorf("swiftCode must be 11 characters")
+	}
+
+	swiftRepo.mu.RLock()
+	defer swiftRepo.mu.RUnlock()
+
+	parent := &models.BranchParent{}
+	for code := range swiftRepo.byPrefix[swiftCodePrefix(branchCode)] {
+		if code == branchCode || models.IsSwiftCodeOfHeadquarter(code) {
+			continue
+		}
+		parent.SiblingBranchCount++
+	}
+	if headquarter, ok := swiftRepo.byCode[models.HeadquarterCodeOf(branchCode)]; ok {
+		mini := toSwiftMini(headquarter)
+		parent.Headquarter = &mini
+	}
+
+	return parent, nil
+}
+
+func (swiftRepo *SwiftRepoMemory) GetByCountryIso2Code(_ context.Context, countryIso2Code string, page models.PageRequest) ([]models.SwiftMini, error) {
+	swiftRepo.mu.RLock()
+	defer swiftRepo.mu.RUnlock()
+
+	codes, ok := swiftRepo.byCountry[countryIso2Code]
+	if !ok && page.After == "" {
+		return nil, sql.ErrNoRows
+	}
+
+	all := make([]models.SwiftMini, 0, len(codes))
+	for _, code := range sortedCodes(codes) {
+		swift := swiftRepo.byCode[code]
+		if page.HeadquarterMissing && !swift.HeadquarterMissing {
+			continue
+		}
+		all = append(all, toSwiftMini(swift))
+	}
+
+	less := func(a, b models.SwiftMini) bool {
+		return a.SwiftCode < b.SwiftCode
+	}
+	if page.SortBy == models.SortByBankName {
+		less = func(a, b models.SwiftMini) bool {
+			if a.BankName != b.BankName {
+				return a.BankName < b.BankName
+			}
+			return a.SwiftCode < b.SwiftCode
+		}
+		sort.SliceStable(all, func(i, j int) bool { return less(all[i], all[j]) })
+	}
+
+	swifts := make([]models.SwiftMini, 0)
+	var cursor *models.SwiftMini
+	if page.After != "" {
+		after, ok := swiftRepo.byCode[page.After]
+		if !ok {
+			after, ok = swiftRepo.deleted[page.After]
+		}
+		if !ok || after.CountryIso2 != countryIso2Code {
+			return nil, sql.ErrNoRows
+		}
+		mini := toSwiftMini(after)
+		cursor = &mini
+	}
+
+	for _, swift := range all {
+		if cursor != nil && !less(*cursor, swift) {
+			continue
+		}
+		if page.Limit > 0 && len(swifts) == page.Limit {
+			break
+		}
+		swifts = append(swifts, swift)
+	}
+
+	return swifts, nil
+}
+
+func (swiftRepo *SwiftRepoMemory) GetCountryNameByIso2Code(_ context.Context, countryIso2Code string) (string, error) {
+	swiftRepo.mu.RLock()
+	defer swiftRepo.mu.RUnlock()
+
+	for code := range swiftRepo.byCountry[countryIso2Code] {
+		return swiftRepo.byCode[code].CountryName, nil
+	}
+
+	return "", sql.ErrNoRows
+}
+
+// Search ranks records by how many of the query's words appear in the bank
+// name, address or town. It's a simple stand-in for the trigram search in
+// Postgres, not an exact match of its ranking.
+func (swiftRepo *SwiftRepoMemory) Search(_ context.Context, search models.SearchQuery) ([]models.SwiftMini, error) {
+	words := strings.Fields(strings.ToLower(search.Query))
+
+	swiftRepo.mu.RLock()
+	defer swiftRepo.mu.RUnlock()
+
+	type match struct {
+		swift models.SwiftMini
+		score int
+	}
+	matches := make([]match, 0)
+
+	for _, swift := range swiftRepo.byCode {
+		if search.Country != "" && swift.CountryIso2 != search.Country {
+			continue
+		}
+
+		document := strings.ToLower(swift.BankName + " " + swift.Address + " " + swift.TownName)
+		score := 0
+		for _, word := range words {
+			if strings.Contains(document, word) {
+				score++
+			}
+		}
+		if score == 0 {
+			continue
+		}
+
+		matches = append(matches, match{swift: toSwiftMini(swift), score: score})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].swift.SwiftCode < matches[j].swift.SwiftCode
+	})
+
+	swifts := make([]models.SwiftMini, 0)
+	for i := search.Offset; i < len(matches); i++ {
+		if search.Limit > 0 && len(swifts) == search.Limit {
+			break
+		}
+		swifts = append(swifts, matches[i].swift)
+	}
+
+	return swifts, nil
+}
+
+func (swiftRepo *SwiftRepoMemory) ForEachSwift(_ context.Context, countryIso2Code string, fn func(*models.Swift) error) error {
+	swiftRepo.mu.RLock()
+	var codes []string
+	if countryIso2Code != "" {
+		codes = sortedCodes(swiftRepo.byCountry[countryIso2Code])
+	} else {
+		codes = make([]string, 0, len(swiftRepo.byCode))
+		for code := range swiftRepo.byCode {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+	}
+	swifts := make([]models.Swift, 0, len(codes))
+	for _, code := range codes {
+		swifts = append(swifts, swiftRepo.byCode[code])
+	}
+	swiftRepo.mu.RUnlock()
+
+	// fn may be slow (it usually writes to a client), so it runs without the lock.
+	for i := range swifts {
+		if err := fn(&swifts[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (swiftRepo *SwiftRepoMemory) AddSwift(ctx context.Context, swift *models.Swift) error {
+	swiftRepo.txMu.Lock()
+	defer swiftRepo.txMu.Unlock()
+
+	return swiftRepo.addSwift(ctx, swift)
+}
+
+func (swiftRepo *SwiftRepoMemory) addSwift(ctx context.Context, swift *models.Swift) error {
+	// Normalize the same way bun does before inserting into Postgres.
+	if err := swift.BeforeAppendModel(ctx, nil); err != nil {
+		return err
+	}
+
+	swiftRepo.mu.Lock()
+	defer swiftRepo.mu.Unlock()
+
+	if _, ok := swiftRepo.byCode[swift.SwiftCode]; ok {
+		return fmt.Errorf("swift code %s already exists", swift.SwiftCode)
+	}
+	previous := swiftRepo.deleted[swift.SwiftCode]
+	delete(swiftRepo.deleted, swift.SwiftCode)
+
+	swiftRepo.touch(swift, previous.CountryIso2)
+	swiftRepo.byCode[swift.SwiftCode] = *swift
+	addToIndex(swiftRepo.byPrefix, swiftCodePrefix(swift.SwiftCode), swift.SwiftCode)
+	addToIndex(swiftRepo.byCountry, swift.CountryIso2, swift.SwiftCode)
+
+	return nil
+}
+
+func (swiftRepo *SwiftRepoMemory) UpdateSwift(ctx context.Context, swift *models.Swift) error {
+	swiftRepo.txMu.Lock()
+	defer swiftRepo.txMu.Unlock()
+
+	return swiftRepo.updateSwift(ctx, swift)
+}
+
+func (swiftRepo *SwiftRepoMemory) updateSwift(ctx context.Context, swift *models.Swift) error {
+	if err := swift.BeforeAppendModel(ctx, nil); err != nil {
+		return err
+	}
+
+	swiftRepo.mu.Lock()
+	defer swiftRepo.mu.Unlock()
+
+	current, ok := swiftRepo.byCode[swift.SwiftCode]
+	if !ok {
+		return nil
+	}
+
+	removeFromIndex(swiftRepo.byCountry, current.CountryIso2, swift.SwiftCode)
+	swiftRepo.touch(swift, current.CountryIso2)
+	swiftRepo.byCode[swift.SwiftCode] = *swift
+	addToIndex(swiftRepo.byCountry, swift.CountryIso2, swift.SwiftCode)
+
+	return nil
+}
+
+func (swiftRepo *SwiftRepoMemory) DeleteSwift(ctx context.Context, swiftCode string) error {
+	swiftRepo.txMu.Lock()
+	defer swiftRepo.txMu.Unlock()
+
+	return swiftRepo.deleteSwift(ctx, swiftCode)
+}
+
+func (swiftRepo *SwiftRepoMemory) deleteSwift(_ context.Context, swiftCode string) error {
+	swiftRepo.mu.Lock()
+	defer swiftRepo.mu.Unlock()
+
+	swiftRepo.softDelete(swiftCode, time.Now())
+
+	return nil
+}
+
+// softDelete moves a swift from the indexes to deleted. The caller must hold mu.
+func (swiftRepo *SwiftRepoMemory) softDelete(swiftCode string, deletedAt time.Time) {
+	swift, ok := swiftRepo.byCode[swiftCode]
+	if !ok {
+		return
+	}
+
+	delete(swiftRepo.byCode, swiftCode)
+	removeFromIndex(swiftRepo.byPrefix, swiftCodePrefix(swiftCode), swiftCode)
+	removeFromIndex(swiftRepo.byCountry, swift.CountryIso2, swiftCode)
+
+	swift.DeletedAt = deletedAt
+	swiftRepo.touch(&swift, "")
+	swiftRepo.deleted[swiftCode] = swift
+}
+
+func (swiftRepo *SwiftRepoMemory) RestoreSwift(ctx context.Context, swiftCode string) error {
+	swiftRepo.txMu.Lock()
+	defer swiftRepo.txMu.Unlock()
+
+	return swiftRepo.restoreSwift(ctx, swiftCode)
+}
+
+func (swiftRepo *SwiftRepoMemory) restoreSwift(_ context.Context, swiftCode string) error {
+	swiftRepo.mu.Lock()
+	defer swiftRepo.mu.Unlock()
+
+	swift, ok := swiftRepo.deleted[swiftCode]
+	if !ok {
+		return sql.ErrNoRows
+	}
+
+	delete(swiftRepo.deleted, swiftCode)
+	swift.DeletedAt = time.Time{}
+	swiftRepo.touch(&swift, "")
+	swiftRepo.byCode[swiftCode] = swift
+	addToIndex(swiftRepo.byPrefix, swiftCodePrefix(swiftCode), swiftCode)
+	addToIndex(swiftRepo.byCountry, swift.CountryIso2, swiftCode)
+
+	return nil
+}
+
+func (swiftRepo *SwiftRepoMemory) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error) {
+	swiftRepo.txMu.Lock()
+	defer swiftRepo.txMu.Unlock()
+
+	return swiftRepo.purgeDeleted(ctx, deletedBefore)
+}
+
+func (swiftRepo *SwiftRepoMemory) purgeDeleted(_ context.Context, deletedBefore time.Time) (int, error) {
+	swiftRepo.mu.Lock()
+	defer swiftRepo.mu.Unlock()
+
+	purged := make(map[string]struct{})
+	for code, swift := range swiftRepo.deleted {
+		if swift.DeletedAt.Before(deletedBefore) {
+			purged[code] = struct{}{}
+			delete(swiftRepo.deleted, code)
+			swiftRepo.touch(&swift, "")
+		}
+	}
+
+	// A branch restored after its headquarter was deleted may still point at it.
+	for _, swifts := range []map[string]models.Swift{swiftRepo.byCode, swiftRepo.deleted} {
+		for code, swift := range swifts {
+			if _, ok := purged[swift.HeadquarterCode]; ok {
+				swift.HeadquarterCode = ""
+				swiftRepo.touch(&swift, "")
+				swifts[code] = swift
+			}
+		}
+	}
+
+	return len(purged), nil
+}
+
+func (swiftRepo *SwiftRepoMemory) LinkBranches(ctx context.Context, headquarterCode string) error {
+	swiftRepo.txMu.Lock()
+	defer swiftRepo.txMu.Unlock()
+
+	return swiftRepo.linkBranches(ctx, headquarterCode)
+}
+
+func (swiftRepo *SwiftRepoMemory) linkBranches(_ context.Context, headquarterCode string) error {
+	swiftRepo.mu.Lock()
+	defer swiftRepo.mu.Unlock()
+
+	if _, ok := swiftRepo.byCode[headquarterCode]; !ok {
+		return nil
+	}
+
+	for code := range swiftRepo.byPrefix[swiftCodePrefix(headquarterCode)] {
+		swift := swiftRepo.byCode[code]
+		if models.IsSwiftCodeOfHeadquarter(code) {
+			continue
+		}
+		swift.HeadquarterCode = headquarterCode
+		swiftRepo.touch(&swift, "")
+		swiftRepo.byCode[code] = swift
+	}
+
+	return nil
+}
+
+func (swiftRepo *SwiftRepoMemory) UnlinkBranches(ctx context.Context, headquarterCode string) error {
+	swiftRepo.txMu.Lock()
+	defer swiftRepo.txMu.Unlock()
+
+	return swiftRepo.unlinkBranches(ctx, headquarterCode)
+}
+
+func (swiftRepo *SwiftRepoMemory) unlinkBranches(_ context.Context, headquarterCode string) error {
+	swiftRepo.mu.Lock()
+	defer swiftRepo.mu.Unlock()
+
+	for code := range swiftRepo.byPrefix[swiftCodePrefix(headquarterCode)] {
+		swift := swiftRepo.byCode[code]
+		if swift.HeadquarterCode != headquarterCode {
+			continue
+		}
+		swift.HeadquarterCode = ""
+		swiftRepo.touch(&swift, "")
+		swiftRepo.byCode[code] = swift
+	}
+
+	return nil
+}
+
+func (swiftRepo *SwiftRepoMemory) DeleteBranches(ctx context.Context, headquarterCode string) error {
+	swiftRepo.txMu.Lock()
+	defer swiftRepo.txMu.Unlock()
+
+	return swiftRepo.deleteBranches(ctx, headquarterCode)
+}
+
+func (swiftRepo *SwiftRepoMemory) deleteBranches(_ context.Context, headquarterCode string) error {
+	swiftRepo.mu.Lock()
+	defer swiftRepo.mu.Unlock()
+
+	deletedAt := time.Now()
+	for _, code := range sortedCodes(swiftRepo.byPrefix[swiftCodePrefix(headquarterCode)]) {
+		if swiftRepo.byCode[code].HeadquarterCode != headquarterCode {
+			continue
+		}
+		swiftRepo.softDelete(code, deletedAt)
+	}
+
+	return nil
+}
+
+func (swiftRepo *SwiftRepoMemory) AddAudit(ctx context.Context, audit *models.SwiftAudit) error {
+	swiftRepo.txMu.Lock()
+	defer swiftRepo.txMu.Unlock()
+
+	return swiftRepo.addAudit(ctx, audit)
+}
+
+func (swiftRepo *SwiftRepoMemory) addAudit(_ context.Context, audit *models.SwiftAudit) error {
+	swiftRepo.mu.Lock()
+	defer swiftRepo.mu.Unlock()
+
+	audit.ID = int64(len(swiftRepo.audits) + 1)
+	swiftRepo.audits = append(swiftRepo.audits, *audit)
+
+	return nil
+}
+
+func (swiftRepo *SwiftRepoMemory) GetAuditBySwiftCode(_ context.Context, swiftCode string) ([]models.SwiftAudit, error) {
+	swiftRepo.mu.RLock()
+	defer swiftRepo.mu.RUnlock()
+
+	audits := make([]models.SwiftAudit, 0)
+	for _, audit := range swiftRepo.audits {
+		if audit.SwiftCode == swiftCode {
+			audits = append(audits, audit)
+		}
+	}
+
+	return audits, nil
+}
+
+func (swiftRepo *SwiftRepoMemory) GetCountryVersion(_ context.Context, countryIso2Code string) (models.CountryVersion, error) {
+	swiftRepo.mu.RLock()
+	defer swiftRepo.mu.RUnlock()
+
+	version, ok := swiftRepo.versions[countryIso2Code]
+	if !ok {
+		return models.CountryVersion{CountryIso2: countryIso2Code}, nil
+	}
+	return version, nil
+}
+
+func (swiftRepo *SwiftRepoMemory) GetSwiftVersion(_ context.Context, swiftCode string) (models.SwiftVersion, error) {
+	swiftRepo.mu.RLock()
+	defer swiftRepo.mu.RUnlock()
+
+	version := models.SwiftVersion{SwiftCode: swiftCode}
+	prefix := swiftCodePrefix(swiftCode)
+	for code := range swiftRepo.byPrefix[prefix] {
+		if updatedAt := swiftRepo.byCode[code].UpdatedAt; updatedAt.After(version.UpdatedAt) {
+			version.UpdatedAt = updatedAt
+		}
+	}
+	for code, swift := range swiftRepo.deleted {
+		if swiftCodePrefix(code) == prefix && swift.UpdatedAt.After(version.UpdatedAt) {
+			version.UpdatedAt = swift.UpdatedAt
+		}
+	}
+	return version, nil
+}
+
+// LockCountryVersion doesn't need to lock anything, since transactions are
+// serialized and RunInTx holds txMu.
+func (swiftRepo *SwiftRepoMemory) LockCountryVersion(ctx context.Context, countryIso2Code string) (models.CountryVersion, error) {
+	return swiftRepo.GetCountryVersion(ctx, countryIso2Code)
+}
+
+func (swiftRepo *SwiftRepoMemory) RunInTx(ctx context.Context, fn func(ctx context.Context, swiftRepo SwiftRepo) error) error {
+	swiftRepo.txMu.Lock()
+	defer swiftRepo.txMu.Unlock()
+
+	return swiftRepo.runInSavepoint(ctx, fn)
+}
+
+// runInSavepoint restores the state from before fn if fn fails.
+func (swiftRepo *SwiftRepoMemory) runInSavepoint(ctx context.Context, fn func(ctx context.Context, swiftRepo SwiftRepo) error) error {
+	swiftRepo.mu.RLock()
+	byCode := make(map[string]models.Swift, len(swiftRepo.byCode))
+	for code, swift := range swiftRepo.byCode {
+		byCode[code] = swift
+	}
+	deleted := make(map[string]models.Swift, len(swiftRepo.deleted))
+	for code, swift := range swiftRepo.deleted {
+		deleted[code] = swift
+	}
+	versions := make(map[string]models.CountryVersion, len(swiftRepo.versions))
+	for country, version := range swiftRepo.versions {
+		versions[country] = version
+	}
+	// Audits are only ever appended, so truncating them undoes fn's.
+	auditCount := len(swiftRepo.audits)
+	swiftRepo.mu.RUnlock()
+
+	err := fn(ctx, swiftRepoMemoryTx{swiftRepo})
+	if err == nil {
+		return nil
+	}
+
+	swiftRepo.mu.Lock()
+	defer swiftRepo.mu.Unlock()
+
+	swiftRepo.byCode = byCode
+	swiftRepo.deleted = deleted
+	swiftRepo.versions = versions
+	swiftRepo.audits = swiftRepo.audits[:auditCount]
+	swiftRepo.byPrefix = make(map[string]map[string]struct{})
+	swiftRepo.byCountry = make(map[string]map[string]struct{})
+	for code, swift := range byCode {
+		addToIndex(swiftRepo.byPrefix, swiftCodePrefix(code), code)
+		addToIndex(swiftRepo.byCountry, swift.CountryIso2, code)
+	}
+
+	return err
+}
+
+// swiftRepoMemoryTx is the SwiftRepo passed to RunInTx callbacks. Its writes
+// and nested RunInTx calls, which act like savepoints, run under the txMu
+// already held by the outer RunInTx instead of waiting for it.
+type swiftRepoMemoryTx struct {
+	*SwiftRepoMemory
+}
+
+func (tx swiftRepoMemoryTx) RunInTx(ctx context.Context, fn func(ctx context.Context, swiftRepo SwiftRepo) error) error {
+	return tx.runInSavepoint(ctx, fn)
+}
+
+func (tx swiftRepoMemoryTx) AddSwift(ctx context.Context, swift *models.Swift) error {
+	return tx.addSwift(ctx, swift)
+}
+
+func (tx swiftRepoMemoryTx) UpdateSwift(ctx context.Context, swift *models.Swift) error {
+	return tx.updateSwift(ctx, swift)
+}
+
+func (tx swiftRepoMemoryTx) DeleteSwift(ctx context.Context, swiftCode string) error {
+	return tx.deleteSwift(ctx, swiftCode)
+}
+
+func (tx swiftRepoMemoryTx) RestoreSwift(ctx context.Context, swiftCode string) error {
+	return tx.restoreSwift(ctx, swiftCode)
+}
+
+func (tx swiftRepoMemoryTx) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error) {
+	return tx.purgeDeleted(ctx, deletedBefore)
+}
+
+func (tx swiftRepoMemoryTx) LinkBranches(ctx context.Context, headquarterCode string) error {
+	return tx.linkBranches(ctx, headquarterCode)
+}
+
+func (tx swiftRepoMemoryTx) UnlinkBranches(ctx context.Context, headquarterCode string) error {
+	return tx.unlinkBranches(ctx, headquarterCode)
+}
+
+func (tx swiftRepoMemoryTx) DeleteBranches(ctx context.Context, headquarterCode string) error {
+	return tx.deleteBranches(ctx, headquarterCode)
+}
+
+func (tx swiftRepoMemoryTx) AddAudit(ctx context.Context, audit *models.SwiftAudit) error {
+	return tx.addAudit(ctx, audit)
+}
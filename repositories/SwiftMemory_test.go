@@ -0,0 +1,482 @@
+package repositories
+
+import (
+	"awesomeProject/models"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newSeededSwiftRepoMemory(t *testing.T) *SwiftRepoMemory {
+	repo := NewSwiftRepoMemory()
+	swifts := []models.Swift{
+		{CountryIso2: "PL", SwiftCode: "ABCDPLPWXXX", BankName: "Bank A", Address: "Warsaw", CountryName: "POLAND", IsHeadquarter: true},
+		{CountryIso2: "PL", SwiftCode: "ABCDPLPW001", BankName: "Bank A", Address: "Krakow", CountryName: "POLAND", HeadquarterCode: "ABCDPLPWXXX"},
+		{CountryIso2: "PL", SwiftCode: "ABCDPLPW002", BankName: "Bank A", Address: "Gdansk", CountryName: "POLAND", HeadquarterCode: "ABCDPLPWXXX"},
+		{CountryIso2: "DE", SwiftCode: "EFGHDEFFXXX", BankName: "Bank B", Address: "Berlin", CountryName: "GERMANY", IsHeadquarter: true},
+	}
+	for i := range swifts {
+		assert.NoError(t, repo.AddSwift(context.Background(), &swifts[i]))
+	}
+	return repo
+}
+
+func TestSwiftRepoMemory_GetBySwiftCode(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	swift, err := repo.GetBySwiftCode(ctx, "ABCDPLPW001")
+	assert.NoError(t, err)
+	assert.Equal(t, "Krakow", swift.Address)
+
+	_, err = repo.GetBySwiftCode(ctx, "NOPENOPEXXX")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestSwiftRepoMemory_GetBranchesBySwiftCode(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	branches, err := repo.GetBranchesBySwiftCode(ctx, "ABCDPLPWXXX")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.SwiftMini{
+		{CountryIso2: "PL", SwiftCode: "ABCDPLPW001", BankName: "Bank A", Address: "Krakow"},
+		{CountryIso2: "PL", SwiftCode: "ABCDPLPW002", BankName: "Bank A", Address: "Gdansk"},
+	}, branches)
+
+	branches, err = repo.GetBranchesBySwiftCode(ctx, "EFGHDEFFXXX")
+	assert.NoError(t, err)
+	assert.Empty(t, branches)
+
+	_, err = repo.GetBranchesBySwiftCode(ctx, "ABCD")
+	assert.Error(t, err)
+}
+
+func TestSwiftRepoMemory_LinkBranches(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	orphan := models.Swift{CountryIso2: "DE", SwiftCode: "IJKLDEFF001", BankName: "Bank C", Address: "Hamburg", CountryName: "GERMANY"}
+	assert.NoError(t, repo.AddSwift(ctx, &orphan))
+
+	assert.True(t, orphan.HeadquarterMissing)
+
+	assert.NoError(t, repo.LinkBranches(ctx, "IJKLDEFFXXX"))
+	stored, err := repo.GetBySwiftCode(ctx, "IJKLDEFF001")
+	assert.NoError(t, err)
+	assert.Empty(t, stored.HeadquarterCode)
+	assert.True(t, stored.HeadquarterMissing)
+
+	headquarter := models.Swift{CountryIso2: "DE", SwiftCode: "IJKLDEFFXXX", BankName: "Bank C", Address: "Munich", CountryName: "GERMANY", IsHeadquarter: true}
+	assert.NoError(t, repo.AddSwift(ctx, &headquarter))
+	assert.NoError(t, repo.LinkBranches(ctx, "IJKLDEFFXXX"))
+
+	branches, err := repo.GetBranchesBySwiftCode(ctx, "IJKLDEFFXXX")
+	assert.NoError(t, err)
+	assert.Len(t, branches, 1)
+	stored, err = repo.GetBySwiftCode(ctx, "IJKLDEFF001")
+	assert.NoError(t, err)
+	assert.False(t, stored.HeadquarterMissing)
+
+	assert.NoError(t, repo.UnlinkBranches(ctx, "IJKLDEFFXXX"))
+	branches, err = repo.GetBranchesBySwiftCode(ctx, "IJKLDEFFXXX")
+	assert.NoError(t, err)
+	assert.Empty(t, branches)
+	_, err = repo.GetBySwiftCode(ctx, "IJKLDEFF001")
+	assert.NoError(t, err)
+}
+
+func TestSwiftRepoMemory_GetBranchParent(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	parent, err := repo.GetBranchParent(ctx, "ABCDPLPW001")
+	assert.NoError(t, err)
+	assert.Equal(t, "ABCDPLPWXXX", parent.Headquarter.SwiftCode)
+	assert.Equal(t, 1, parent.SiblingBranchCount)
+
+	assert.NoError(t, repo.DeleteSwift(ctx, "ABCDPLPWXXX"))
+	parent, err = repo.GetBranchParent(ctx, "ABCDPLPW001")
+	assert.NoError(t, err)
+	assert.Nil(t, parent.Headquarter)
+	assert.Equal(t, 1, parent.SiblingBranchCount)
+
+	_, err = repo.GetBranchParent(ctx, "ABCD")
+	assert.Error(t, err)
+}
+
+func TestSwiftRepoMemory_DeleteBranches(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	assert.NoError(t, repo.DeleteBranches(ctx, "ABCDPLPWXXX"))
+
+	_, err := repo.GetBySwiftCode(ctx, "ABCDPLPW001")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = repo.GetBySwiftCode(ctx, "ABCDPLPWXXX")
+	assert.NoError(t, err)
+
+	swifts, err := repo.GetByCountryIso2Code(ctx, "PL", models.PageRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, swifts, 1)
+}
+
+func TestSwiftRepoMemory_GetByCountryIso2Code(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	swifts, err := repo.GetByCountryIso2Code(ctx, "PL", models.PageRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, swifts, 3)
+
+	countryName, err := repo.GetCountryNameByIso2Code(ctx, "DE")
+	assert.NoError(t, err)
+	assert.Equal(t, "GERMANY", countryName)
+
+	_, err = repo.GetByCountryIso2Code(ctx, "US", models.PageRequest{})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	swifts, err = repo.GetByCountryIso2Code(ctx, "PL", models.PageRequest{HeadquarterMissing: true})
+	assert.NoError(t, err)
+	assert.Empty(t, swifts)
+
+	assert.NoError(t, repo.UnlinkBranches(ctx, "ABCDPLPWXXX"))
+	swifts, err = repo.GetByCountryIso2Code(ctx, "PL", models.PageRequest{HeadquarterMissing: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.SwiftMini{
+		{CountryIso2: "PL", SwiftCode: "ABCDPLPW001", BankName: "Bank A", Address: "Krakow", HeadquarterMissing: true},
+		{CountryIso2: "PL", SwiftCode: "ABCDPLPW002", BankName: "Bank A", Address: "Gdansk", HeadquarterMissing: true},
+	}, swifts)
+
+	_, err = repo.GetCountryNameByIso2Code(ctx, "US")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestSwiftRepoMemory_AddSwift(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	swift := models.Swift{CountryIso2: "us", SwiftCode: "ijklusnyxxx", BankName: "Bank C", Address: "New York", CountryName: "united states", IsHeadquarter: true}
+	assert.NoError(t, repo.AddSwift(ctx, &swift))
+
+	stored, err := repo.GetBySwiftCode(ctx, "IJKLUSNYXXX")
+	assert.NoError(t, err)
+	assert.Equal(t, "US", stored.CountryIso2)
+	assert.Equal(t, "UNITED STATES", stored.CountryName)
+
+	duplicate := models.Swift{CountryIso2: "PL", SwiftCode: "ABCDPLPWXXX", BankName: "Bank A", Address: "Warsaw", CountryName: "POLAND", IsHeadquarter: true}
+	assert.Error(t, repo.AddSwift(ctx, &duplicate))
+}
+
+func TestSwiftRepoMemory_DeleteSwift(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	assert.NoError(t, repo.DeleteSwift(ctx, "EFGHDEFFXXX"))
+
+	_, err := repo.GetBySwiftCode(ctx, "EFGHDEFFXXX")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = repo.GetByCountryIso2Code(ctx, "DE", models.PageRequest{})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	assert.NoError(t, repo.DeleteSwift(ctx, "EFGHDEFFXXX"))
+}
+
+func TestSwiftRepoMemory_RestoreSwift(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, repo.RestoreSwift(ctx, "EFGHDEFFXXX"), sql.ErrNoRows)
+
+	assert.NoError(t, repo.DeleteSwift(ctx, "EFGHDEFFXXX"))
+	assert.NoError(t, repo.RestoreSwift(ctx, "EFGHDEFFXXX"))
+
+	swift, err := repo.GetBySwiftCode(ctx, "EFGHDEFFXXX")
+	assert.NoError(t, err)
+	assert.True(t, swift.DeletedAt.IsZero())
+	swifts, err := repo.GetByCountryIso2Code(ctx, "DE", models.PageRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, swifts, 1)
+
+	assert.NoError(t, repo.DeleteSwift(ctx, "EFGHDEFFXXX"))
+	revived := models.Swift{CountryIso2: "DE", SwiftCode: "EFGHDEFFXXX", BankName: "Bank D", Address: "Bonn", CountryName: "GERMANY", IsHeadquarter: true}
+	assert.NoError(t, repo.AddSwift(ctx, &revived))
+	assert.ErrorIs(t, repo.RestoreSwift(ctx, "EFGHDEFFXXX"), sql.ErrNoRows)
+}
+
+func TestSwiftRepoMemory_PurgeDeleted(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	assert.NoError(t, repo.DeleteSwift(ctx, "ABCDPLPWXXX"))
+	assert.NoError(t, repo.DeleteSwift(ctx, "ABCDPLPW002"))
+
+	purged, err := repo.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, purged)
+
+	purged, err = repo.PurgeDeleted(ctx, time.Now().Add(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, purged)
+
+	assert.ErrorIs(t, repo.RestoreSwift(ctx, "ABCDPLPWXXX"), sql.ErrNoRows)
+	branch, err := repo.GetBySwiftCode(ctx, "ABCDPLPW001")
+	assert.NoError(t, err)
+	assert.Empty(t, branch.HeadquarterCode)
+}
+
+func TestSwiftRepoMemory_UpdateSwift(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	swift := models.Swift{CountryIso2: "CZ", SwiftCode: "EFGHDEFFXXX", BankName: "Bank B", Address: "Prague", CountryName: "CZECHIA", IsHeadquarter: true}
+	assert.NoError(t, repo.UpdateSwift(ctx, &swift))
+
+	stored, err := repo.GetBySwiftCode(ctx, "EFGHDEFFXXX")
+	assert.NoError(t, err)
+	assert.Equal(t, "Prague", stored.Address)
+
+	_, err = repo.GetByCountryIso2Code(ctx, "DE", models.PageRequest{})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	swifts, err := repo.GetByCountryIso2Code(ctx, "CZ", models.PageRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, swifts, 1)
+}
+
+func TestSwiftRepoMemory_GetByCountryIso2Code_Pagination(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+	branch := models.Swift{CountryIso2: "PL", SwiftCode: "AAAAPLPWXXX", BankName: "Bank Z", Address: "Lodz", CountryName: "POLAND", IsHeadquarter: true}
+	assert.NoError(t, repo.AddSwift(ctx, &branch))
+
+	codes := func(swifts []models.SwiftMini) []string {
+		result := make([]string, 0, len(swifts))
+		for _, swift := range swifts {
+			result = append(result, swift.SwiftCode)
+		}
+		return result
+	}
+
+	swifts, err := repo.GetByCountryIso2Code(ctx, "PL", models.PageRequest{Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"AAAAPLPWXXX", "ABCDPLPW001"}, codes(swifts))
+
+	swifts, err = repo.GetByCountryIso2Code(ctx, "PL", models.PageRequest{Limit: 2, After: "ABCDPLPW001"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ABCDPLPW002", "ABCDPLPWXXX"}, codes(swifts))
+
+	swifts, err = repo.GetByCountryIso2Code(ctx, "PL", models.PageRequest{Limit: 3, SortBy: models.SortByBankName})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ABCDPLPW001", "ABCDPLPW002", "ABCDPLPWXXX"}, codes(swifts))
+
+	swifts, err = repo.GetByCountryIso2Code(ctx, "PL", models.PageRequest{Limit: 3, After: "ABCDPLPWXXX", SortBy: models.SortByBankName})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"AAAAPLPWXXX"}, codes(swifts))
+
+	swifts, err = repo.GetByCountryIso2Code(ctx, "PL", models.PageRequest{After: "AAAAPLPWXXX", SortBy: models.SortByBankName})
+	assert.NoError(t, err)
+	assert.Empty(t, swifts)
+
+	// A cursor deleted since it was handed out still marks its place.
+	assert.NoError(t, repo.DeleteSwift(ctx, "ABCDPLPW001"))
+	swifts, err = repo.GetByCountryIso2Code(ctx, "PL", models.PageRequest{Limit: 2, After: "ABCDPLPW001"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ABCDPLPW002", "ABCDPLPWXXX"}, codes(swifts))
+
+	_, err = repo.GetByCountryIso2Code(ctx, "PL", models.PageRequest{After: "ABCDPLPW003"})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = repo.GetByCountryIso2Code(ctx, "PL", models.PageRequest{After: "EFGHDEFFXXX"})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestSwiftRepoMemory_Search(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	swifts, err := repo.Search(ctx, models.SearchQuery{Query: "bank krakow"})
+	assert.NoError(t, err)
+	assert.Len(t, swifts, 4)
+	assert.Equal(t, "ABCDPLPW001", swifts[0].SwiftCode)
+
+	swifts, err = repo.Search(ctx, models.SearchQuery{Query: "bank", Country: "DE"})
+	assert.NoError(t, err)
+	assert.Len(t, swifts, 1)
+	assert.Equal(t, "EFGHDEFFXXX", swifts[0].SwiftCode)
+
+	swifts, err = repo.Search(ctx, models.SearchQuery{Query: "bank", Limit: 2, Offset: 3})
+	assert.NoError(t, err)
+	assert.Len(t, swifts, 1)
+
+	swifts, err = repo.Search(ctx, models.SearchQuery{Query: "nowhere"})
+	assert.NoError(t, err)
+	assert.Empty(t, swifts)
+}
+
+func TestSwiftRepoMemory_RunInTx(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	err := repo.RunInTx(ctx, func(ctx context.Context, tx SwiftRepo) error {
+		swift := models.Swift{CountryIso2: "US", SwiftCode: "IJKLUSNYXXX", BankName: "Bank C", Address: "New York", CountryName: "UNITED STATES", IsHeadquarter: true}
+		assert.NoError(t, tx.AddSwift(ctx, &swift))
+		assert.NoError(t, tx.DeleteSwift(ctx, "EFGHDEFFXXX"))
+
+		err := tx.RunInTx(ctx, func(ctx context.Context, tx SwiftRepo) error {
+			return tx.DeleteSwift(ctx, "ABCDPLPW001")
+		})
+		assert.NoError(t, err)
+
+		return sql.ErrTxDone
+	})
+	assert.ErrorIs(t, err, sql.ErrTxDone)
+
+	_, err = repo.GetBySwiftCode(ctx, "IJKLUSNYXXX")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = repo.GetBySwiftCode(ctx, "EFGHDEFFXXX")
+	assert.NoError(t, err)
+	branches, err := repo.GetBranchesBySwiftCode(ctx, "ABCDPLPWXXX")
+	assert.NoError(t, err)
+	assert.Len(t, branches, 2)
+
+	err = repo.RunInTx(ctx, func(ctx context.Context, tx SwiftRepo) error {
+		return tx.DeleteSwift(ctx, "EFGHDEFFXXX")
+	})
+	assert.NoError(t, err)
+	_, err = repo.GetBySwiftCode(ctx, "EFGHDEFFXXX")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestSwiftRepoMemory_RunInTx_ConcurrentWrite(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	written := make(chan error)
+	err := repo.RunInTx(ctx, func(ctx context.Context, tx SwiftRepo) error {
+		assert.NoError(t, tx.DeleteSwift(ctx, "EFGHDEFFXXX"))
+
+		// The write waits for the transaction, so the rollback can't undo it.
+		go func() {
+			swift := models.Swift{CountryIso2: "US", SwiftCode: "IJKLUSNYXXX", BankName: "Bank C", Address: "New York", CountryName: "UNITED STATES", IsHeadquarter: true}
+			written <- repo.AddSwift(ctx, &swift)
+		}()
+		return errors.New("rollback")
+	})
+	assert.Error(t, err)
+	assert.NoError(t, <-written)
+
+	_, err = repo.GetBySwiftCode(ctx, "IJKLUSNYXXX")
+	assert.NoError(t, err)
+	_, err = repo.GetBySwiftCode(ctx, "EFGHDEFFXXX")
+	assert.NoError(t, err)
+}
+
+func TestSwiftRepoMemory_Audit(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	assert.NoError(t, repo.AddAudit(ctx, &models.SwiftAudit{SwiftCode: "ABCDPLPWXXX", Action: models.AuditActionAdd}))
+	err := repo.RunInTx(ctx, func(ctx context.Context, tx SwiftRepo) error {
+		assert.NoError(t, tx.AddAudit(ctx, &models.SwiftAudit{SwiftCode: "ABCDPLPWXXX", Action: models.AuditActionDelete}))
+		return errors.New("rollback")
+	})
+	assert.Error(t, err)
+	assert.NoError(t, repo.AddAudit(ctx, &models.SwiftAudit{SwiftCode: "EFGHDEFFXXX", Action: models.AuditActionAdd}))
+
+	audits, err := repo.GetAuditBySwiftCode(ctx, "ABCDPLPWXXX")
+	assert.NoError(t, err)
+	assert.Len(t, audits, 1)
+	assert.Equal(t, int64(1), audits[0].ID)
+	assert.Equal(t, models.AuditActionAdd, audits[0].Action)
+
+	audits, err = repo.GetAuditBySwiftCode(ctx, "IJKLDEFFXXX")
+	assert.NoError(t, err)
+	assert.Empty(t, audits)
+}
+
+func TestSwiftRepoMemory_ForEachSwift(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	var codes []string
+	err := repo.ForEachSwift(ctx, "", func(swift *models.Swift) error {
+		codes = append(codes, swift.SwiftCode)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ABCDPLPW001", "ABCDPLPW002", "ABCDPLPWXXX", "EFGHDEFFXXX"}, codes)
+
+	codes = nil
+	err = repo.ForEachSwift(ctx, "DE", func(swift *models.Swift) error {
+		codes = append(codes, swift.SwiftCode)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"EFGHDEFFXXX"}, codes)
+
+	err = repo.ForEachSwift(ctx, "", func(swift *models.Swift) error {
+		return sql.ErrConnDone
+	})
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+}
+
+func TestSwiftRepoMemory_CountryVersion(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	version, err := repo.GetCountryVersion(ctx, "PL")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), version.Version)
+	assert.False(t, version.UpdatedAt.IsZero())
+
+	version, err = repo.GetCountryVersion(ctx, "US")
+	assert.NoError(t, err)
+	assert.Equal(t, models.CountryVersion{CountryIso2: "US"}, version)
+
+	// A code filed under another country bumps both.
+	assert.NoError(t, repo.AddSwift(ctx, &models.Swift{CountryIso2: "DE", SwiftCode: "ABCDPLPW003", BankName: "Bank A", Address: "Berlin", CountryName: "GERMANY"}))
+	version, _ = repo.GetCountryVersion(ctx, "PL")
+	assert.Equal(t, int64(4), version.Version)
+	version, _ = repo.GetCountryVersion(ctx, "DE")
+	assert.Equal(t, int64(2), version.Version)
+
+	assert.NoError(t, repo.DeleteSwift(ctx, "EFGHDEFFXXX"))
+	version, _ = repo.GetCountryVersion(ctx, "DE")
+	assert.Equal(t, int64(3), version.Version)
+
+	err = repo.RunInTx(ctx, func(ctx context.Context, txRepo SwiftRepo) error {
+		assert.NoError(t, txRepo.UnlinkBranches(ctx, "ABCDPLPWXXX"))
+		return errors.New("rollback")
+	})
+	assert.Error(t, err)
+	version, _ = repo.LockCountryVersion(ctx, "PL")
+	assert.Equal(t, int64(4), version.Version)
+}
+
+func TestSwiftRepoMemory_GetSwiftVersion(t *testing.T) {
+	repo := newSeededSwiftRepoMemory(t)
+	ctx := context.Background()
+
+	version, err := repo.GetSwiftVersion(ctx, "ABCDPLPWXXX")
+	assert.NoError(t, err)
+	assert.False(t, version.UpdatedAt.IsZero())
+
+	// Changes to other banks, even in the same country, leave it alone.
+	assert.NoError(t, repo.AddSwift(ctx, &models.Swift{CountryIso2: "PL", SwiftCode: "WXYZPLPWXXX", BankName: "Bank C", Address: "Lodz", CountryName: "POLAND", IsHeadquarter: true}))
+	unchanged, _ := repo.GetSwiftVersion(ctx, "ABCDPLPWXXX")
+	assert.Equal(t, version, unchanged)
+
+	// Deleting a branch changes the headquarter's branches.
+	assert.NoError(t, repo.DeleteSwift(ctx, "ABCDPLPW002"))
+	changed, _ := repo.GetSwiftVersion(ctx, "ABCDPLPWXXX")
+	assert.True(t, changed.UpdatedAt.After(version.UpdatedAt))
+	assert.NotEqual(t, version.ETag(), changed.ETag())
+
+	version, err = repo.GetSwiftVersion(ctx, "NOPENOPEXXX")
+	assert.NoError(t, err)
+	assert.True(t, version.UpdatedAt.IsZero())
+}
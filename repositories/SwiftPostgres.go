@@ -2,14 +2,22 @@ package repositories
 
 import (
 	"awesomeProject/dbs"
+	"awesomeProject/logging"
 	"awesomeProject/models"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"github.com/uptrace/bun"
+	"log/slog"
+	"strings"
+	"time"
 )
 
 type SwiftRepoPostgres struct {
 	Db dbs.SwiftDb
+	// Logger is slog.Default() when nil.
+	Logger *slog.Logger
 }
 
 func (swiftRepo SwiftRepoPostgres) GetBySwiftCode(ctx context.Context, swiftCode string) (*models.Swift, error) {
@@ -25,26 +33,99 @@ func (swiftRepo SwiftRepoPostgres) GetBranchesBySwiftCode(ctx context.Context, s
 
 	branches := make([]models.SwiftMini, 0)
 	query := `
-        SELECT address, swifts.bank_name, country_iso2_code, is_headquarter, swift_code 
+        SELECT address, swifts.bank_name, country_iso2_code, is_headquarter, swift_code,
+               code_type, town_name, time_zone
         FROM swifts 
-        WHERE LEFT(swift_code, 8) = ? AND swift_code != ?
+        WHERE headquarter_code = ? AND deleted_at IS NULL
+        ORDER BY swift_code
     `
 
-	err := swiftRepo.Db.NewRaw(query, swiftCode[:8], swiftCode).Scan(ctx, &branches)
+	err := swiftRepo.Db.NewRaw(query, swiftCode).Scan(ctx, &branches)
 
 	return branches, err
 }
 
-func (swiftRepo SwiftRepoPostgres) GetByCountryIso2Code(ctx context.Context, countryIso2Code string) ([]models.SwiftMini, error) {
+func (swiftRepo SwiftRepoPostgres) GetBranchParent(ctx context.Context, branchCode string) (*models.BranchParent, error) {
+	if len(branchCode) != 11 {
+		return nil, fmt.Errorf("swiftCode must be 11 characters")
+	}
+
+	// The headquarter is left joined so the sibling count is returned even
+	// when it doesn't exist; its columns are then scanned as zero values.
+	var row struct {
+		models.SwiftMini
+		SiblingBranchCount int `bun:"sibling_branch_count"`
+	}
+	query := `
+        SELECT hq.address, hq.bank_name, hq.country_iso2_code, hq.is_headquarter, hq.swift_code,
+               hq.code_type, hq.town_name, hq.time_zone,
+               (SELECT COUNT(*) FROM swifts
+                WHERE LEFT(swift_code, 8) = LEFT(?, 8)
+                  AND RIGHT(swift_code, 3) != 'XXX'
+                  AND swift_code != ?
+                  AND deleted_at IS NULL) AS sibling_branch_count
+        FROM (SELECT 1) AS one
+        LEFT JOIN swifts AS hq ON hq.swift_code = ? AND hq.deleted_at IS NULL
+    `
+
+	err := swiftRepo.Db.NewRaw(query, branchCode, branchCode, models.HeadquarterCodeOf(branchCode)).Scan(ctx, &row)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := &models.BranchParent{SiblingBranchCount: row.SiblingBranchCount}
+	if row.SwiftCode != "" {
+		parent.Headquarter = &row.SwiftMini
+	}
+	return parent, nil
+}
+
+func (swiftRepo SwiftRepoPostgres) GetByCountryIso2Code(ctx context.Context, countryIso2Code string, page models.PageRequest) ([]models.SwiftMini, error) {
 	branches := make([]models.SwiftMini, 0)
 	query := `
-        SELECT address, bank_name, country_iso2_code, is_headquarter, swift_code 
+        SELECT address, bank_name, country_iso2_code, is_headquarter, swift_code,
+               code_type, town_name, time_zone, headquarter_missing
         FROM swifts 
-        WHERE swifts.country_iso2_code = ?
+        WHERE swifts.country_iso2_code = ? AND deleted_at IS NULL
     `
+	args := []interface{}{countryIso2Code}
 
-	err := swiftRepo.Db.NewRaw(query, countryIso2Code).Scan(ctx, &branches)
-	if len(branches) == 0 {
+	if page.HeadquarterMissing {
+		query += ` AND headquarter_missing`
+	}
+
+	orderBy := "swift_code"
+	if page.SortBy == models.SortByBankName {
+		orderBy = "bank_name, swift_code"
+	}
+
+	if page.After != "" {
+		// The cursor may have been deleted since it was handed out, so deleted
+		// swifts are looked up too.
+		var bankName string
+		err := swiftRepo.Db.NewRaw(`SELECT bank_name FROM swifts WHERE swift_code = ? AND country_iso2_code = ?`,
+			page.After, countryIso2Code).Scan(ctx, &bankName)
+		if err != nil {
+			return nil, err
+		}
+
+		if page.SortBy == models.SortByBankName {
+			query += ` AND (bank_name, swift_code) > (?, ?)`
+			args = append(args, bankName, page.After)
+		} else {
+			query += ` AND swift_code > ?`
+			args = append(args, page.After)
+		}
+	}
+
+	query += ` ORDER BY ` + orderBy
+	if page.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, page.Limit)
+	}
+
+	err := swiftRepo.Db.NewRaw(query, args...).Scan(ctx, &branches)
+	if len(branches) == 0 && page.After == "" {
 		return nil, sql.ErrNoRows
 	}
 	return branches, err
@@ -56,18 +137,115 @@ func (swiftRepo SwiftRepoPostgres) GetCountryNameByIso2Code(ctx context.Context,
 	query := `
         SELECT swifts.country_name
         FROM swifts
-        WHERE swifts.country_iso2_code = ?
+        WHERE swifts.country_iso2_code = ? AND deleted_at IS NULL
         LIMIT 1
     `
 
 	err := swiftRepo.Db.NewRaw(query, countryIso2Code).Scan(ctx, &countryName)
-	fmt.Println(countryIso2Code, countryName)
+	logging.OrDefault(swiftRepo.Logger).DebugContext(ctx, "country name lookup", "countryIso2Code", countryIso2Code, "countryName", countryName)
 
 	return countryName, err
 }
 
+func (swiftRepo SwiftRepoPostgres) Search(ctx context.Context, search models.SearchQuery) ([]models.SwiftMini, error) {
+	swifts := make([]models.SwiftMini, 0)
+	// The expression must match idx_swift_search_trgm for the index to be used.
+	const document = `(bank_name || ' ' || address || ' ' || town_name)`
+	query := `
+        SELECT address, bank_name, country_iso2_code, is_headquarter, swift_code,
+               code_type, town_name, time_zone, headquarter_missing
+        FROM swifts
+        WHERE deleted_at IS NULL AND (? <% ` + document + ` OR ` + document + ` ILIKE ?)
+    `
+	args := []interface{}{search.Query, "%" + escapeLike(search.Query) + "%"}
+
+	if search.Country != "" {
+		query += ` AND country_iso2_code = ?`
+		args = append(args, search.Country)
+	}
+
+	query += ` ORDER BY word_similarity(?, ` + document + `) DESC, swift_code LIMIT ? OFFSET ?`
+	args = append(args, search.Query, search.Limit, search.Offset)
+
+	err := swiftRepo.Db.NewRaw(query, args...).Scan(ctx, &swifts)
+
+	return swifts, err
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+func (swiftRepo SwiftRepoPostgres) ForEachSwift(ctx context.Context, countryIso2Code string, fn func(*models.Swift) error) error {
+	query := swiftRepo.Db.NewSelect().
+		Model((*models.Swift)(nil)).
+		Column("country_iso2_code", "swift_code", "code_type", "bank_name", "address",
+			"town_name", "country_name", "time_zone", "is_headquarter", "headquarter_code").
+		Order("swift_code")
+	if countryIso2Code != "" {
+		query = query.Where("country_iso2_code = ?", countryIso2Code)
+	}
+
+	rows, err := query.Rows(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var swift models.Swift
+		var headquarterCode sql.NullString
+		err := rows.Scan(&swift.CountryIso2, &swift.SwiftCode, &swift.CodeType, &swift.BankName, &swift.Address,
+			&swift.TownName, &swift.CountryName, &swift.TimeZone, &swift.IsHeadquarter, &headquarterCode)
+		if err != nil {
+			return err
+		}
+		swift.HeadquarterCode = headquarterCode.String
+		if err := fn(&swift); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+var revivedColumns = []string{
+	"country_iso2_code",
+	"code_type",
+	"bank_name",
+	"address",
+	"town_name",
+	"country_name",
+	"time_zone",
+	"is_headquarter",
+	"headquarter_code",
+	"deleted_at",
+}
+
+// AddSwift inserts the swift code, or overwrites it if it was deleted but not
+// purged yet, since the deleted row still holds the primary key.
 func (swiftRepo SwiftRepoPostgres) AddSwift(ctx context.Context, swift *models.Swift) error {
-	_, err := swiftRepo.Db.NewInsert().Model(swift).Exec(ctx)
+	query := swiftRepo.Db.NewInsert().Model(swift).On("CONFLICT (swift_code) DO UPDATE")
+	for _, column := range revivedColumns {
+		query = query.Set("? = EXCLUDED.?", bun.Ident(column), bun.Ident(column))
+	}
+
+	res, err := query.Where("s.deleted_at IS NOT NULL").Exec(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("swift code %s already exists", swift.SwiftCode)
+	}
+	return nil
+}
+
+func (swiftRepo SwiftRepoPostgres) UpdateSwift(ctx context.Context, swift *models.Swift) error {
+	_, err := swiftRepo.Db.NewUpdate().Model(swift).WherePK().Exec(ctx)
 
 	return err
 }
@@ -77,3 +255,136 @@ func (swiftRepo SwiftRepoPostgres) DeleteSwift(ctx context.Context, swiftCode st
 
 	return err
 }
+
+func (swiftRepo SwiftRepoPostgres) LinkBranches(ctx context.Context, headquarterCode string) error {
+	_, err := swiftRepo.Db.NewUpdate().
+		Model((*models.Swift)(nil)).
+		Set("headquarter_code = ?", headquarterCode).
+		Where("LEFT(swift_code, 8) = LEFT(?, 8)", headquarterCode).
+		Where("swift_code != ?", headquarterCode).
+		Where("RIGHT(swift_code, 3) != 'XXX'").
+		Where("EXISTS (SELECT 1 FROM swifts WHERE swift_code = ? AND deleted_at IS NULL)", headquarterCode).
+		Exec(ctx)
+
+	return err
+}
+
+func (swiftRepo SwiftRepoPostgres) UnlinkBranches(ctx context.Context, headquarterCode string) error {
+	_, err := swiftRepo.Db.NewUpdate().
+		Model((*models.Swift)(nil)).
+		Set("headquarter_code = NULL").
+		Where("headquarter_code = ?", headquarterCode).
+		Exec(ctx)
+
+	return err
+}
+
+func (swiftRepo SwiftRepoPostgres) DeleteBranches(ctx context.Context, headquarterCode string) error {
+	_, err := swiftRepo.Db.NewDelete().Model(&models.Swift{}).Where("headquarter_code = ?", headquarterCode).Exec(ctx)
+
+	return err
+}
+
+func (swiftRepo SwiftRepoPostgres) RestoreSwift(ctx context.Context, swiftCode string) error {
+	res, err := swiftRepo.Db.NewUpdate().
+		Model((*models.Swift)(nil)).
+		Set("deleted_at = NULL").
+		Where("swift_code = ?", swiftCode).
+		WhereDeleted().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (swiftRepo SwiftRepoPostgres) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error) {
+	purged := 0
+	err := swiftRepo.Db.RunInTx(ctx, func(ctx context.Context, db dbs.SwiftDb) error {
+		// A branch restored after its headquarter was deleted may still point at it.
+		_, err := db.NewUpdate().
+			Model((*models.Swift)(nil)).
+			Set("headquarter_code = NULL").
+			Where("headquarter_code IN (SELECT swift_code FROM swifts WHERE deleted_at < ?)", deletedBefore).
+			WhereAllWithDeleted().
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		res, err := db.NewDelete().
+			Model((*models.Swift)(nil)).
+			Where("deleted_at < ?", deletedBefore).
+			WhereDeleted().
+			ForceDelete().
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		rows, err := res.RowsAffected()
+		purged = int(rows)
+		return err
+	})
+
+	return purged, err
+}
+
+func (swiftRepo SwiftRepoPostgres) AddAudit(ctx context.Context, audit *models.SwiftAudit) error {
+	_, err := swiftRepo.Db.NewInsert().Model(audit).Exec(ctx)
+
+	return err
+}
+
+func (swiftRepo SwiftRepoPostgres) GetAuditBySwiftCode(ctx context.Context, swiftCode string) ([]models.SwiftAudit, error) {
+	audits := make([]models.SwiftAudit, 0)
+	err := swiftRepo.Db.NewSelect().Model(&audits).Where("swift_code = ?", swiftCode).Order("id").Scan(ctx)
+
+	return audits, err
+}
+
+func (swiftRepo SwiftRepoPostgres) GetCountryVersion(ctx context.Context, countryIso2Code string) (models.CountryVersion, error) {
+	return swiftRepo.getCountryVersion(ctx, countryIso2Code, false)
+}
+
+func (swiftRepo SwiftRepoPostgres) GetSwiftVersion(ctx context.Context, swiftCode string) (models.SwiftVersion, error) {
+	var updatedAt bun.NullTime
+	query := `
+        SELECT MAX(updated_at)
+        FROM swifts
+        WHERE LEFT(swift_code, 8) = LEFT(?, 8)
+    `
+
+	err := swiftRepo.Db.NewRaw(query, swiftCode).Scan(ctx, &updatedAt)
+	return models.SwiftVersion{SwiftCode: swiftCode, UpdatedAt: updatedAt.Time}, err
+}
+
+func (swiftRepo SwiftRepoPostgres) LockCountryVersion(ctx context.Context, countryIso2Code string) (models.CountryVersion, error) {
+	return swiftRepo.getCountryVersion(ctx, countryIso2Code, true)
+}
+
+func (swiftRepo SwiftRepoPostgres) getCountryVersion(ctx context.Context, countryIso2Code string, lock bool) (models.CountryVersion, error) {
+	version := models.CountryVersion{CountryIso2: countryIso2Code}
+	query := swiftRepo.Db.NewSelect().Model(&version).WherePK()
+	if lock {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.CountryVersion{CountryIso2: countryIso2Code}, nil
+	}
+	return version, err
+}
+
+func (swiftRepo SwiftRepoPostgres) RunInTx(ctx context.Context, fn func(ctx context.Context, swiftRepo SwiftRepo) error) error {
+	return swiftRepo.Db.RunInTx(ctx, func(ctx context.Context, db dbs.SwiftDb) error {
+		return fn(ctx, SwiftRepoPostgres{Db: db, Logger: swiftRepo.Logger})
+	})
+}
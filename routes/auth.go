@@ -0,0 +1,96 @@
+package routes
+
+import (
+	"awesomeProject/customErrors"
+	"awesomeProject/models"
+	"awesomeProject/repositories"
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+const principalKindApiKey = "apikey"
+
+// ErrNoCredentials is returned by an Authenticator when the request carries no
+// credentials it handles, so the next one can try.
+var ErrNoCredentials = errors.New("no credentials")
+
+// Authenticator identifies the principal a request is made on behalf of.
+// Invalid credentials are reported as a *customErrors.HttpError.
+type Authenticator interface {
+	Authenticate(r *http.Request) (models.Principal, error)
+}
+
+// requiredScope is the scope a request needs: reads need models.ScopeRead,
+// everything else models.ScopeWrite.
+func requiredScope(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return models.ScopeRead
+	default:
+		return models.ScopeWrite
+	}
+}
+
+// authenticate asks each authenticator in turn for the principal of the
+// request, rejects it if none has one or its scope is too narrow, and puts
+// the principal in the request context.
+func authenticate(authenticators ...Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, authenticator := range authenticators {
+			principal, err := authenticator.Authenticate(c.Request)
+			if errors.Is(err, ErrNoCredentials) {
+				continue
+			}
+			if err != nil {
+				var httpErr *customErrors.HttpError
+				if !errors.As(err, &httpErr) {
+					httpErr = customErrors.ErrUnknown
+				}
+				abort(c, httpErr)
+				return
+			}
+
+			if !principal.Allows(requiredScope(c.Request.Method)) {
+				abort(c, customErrors.ErrForbidden)
+				return
+			}
+
+			c.Request = c.Request.WithContext(models.WithPrincipal(c.Request.Context(), principal))
+			c.Next()
+			return
+		}
+
+		abort(c, customErrors.ErrUnauthorized)
+	}
+}
+
+func abort(c *gin.Context, err *customErrors.HttpError) {
+	err.Send(c)
+	c.Abort()
+}
+
+// apiKeyAuthenticator accepts the keys in X-API-Key stored by apiKeyRepo.
+type apiKeyAuthenticator struct {
+	apiKeyRepo repositories.ApiKeyRepo
+}
+
+func (authenticator apiKeyAuthenticator) Authenticate(r *http.Request) (models.Principal, error) {
+	key := r.Header.Get(apiKeyHeader)
+	if key == "" {
+		return models.Principal{}, ErrNoCredentials
+	}
+
+	apiKey, err := authenticator.apiKeyRepo.GetByHash(r.Context(), models.HashApiKey(key))
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Principal{}, customErrors.ErrUnauthorized
+	}
+	if err != nil {
+		return models.Principal{}, err
+	}
+
+	return models.Principal{Kind: principalKindApiKey, Name: apiKey.Name, Scope: apiKey.Scope}, nil
+}
@@ -0,0 +1,123 @@
+package routes
+
+import (
+	"awesomeProject/mocks"
+	"awesomeProject/models"
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthenticate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockApiKeyRepo := mocks.NewMockApiKeyRepo(ctrl)
+
+	readKey := &models.ApiKey{Name: "reader", Scope: models.ScopeRead}
+	writeKey := &models.ApiKey{Name: "writer", Scope: models.ScopeWrite}
+
+	tests := []struct {
+		name           string
+		method         string
+		key            string
+		mockSetup      func()
+		expectedStatus int
+		expectedActor  string
+	}{
+		{
+			name:           "Missing key",
+			method:         http.MethodGet,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "Unknown or revoked key",
+			method: http.MethodGet,
+			key:    "swk_unknown",
+			mockSetup: func() {
+				mockApiKeyRepo.EXPECT().GetByHash(gomock.Any(), models.HashApiKey("swk_unknown")).Return(nil, sql.ErrNoRows)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "Repository error",
+			method: http.MethodGet,
+			key:    "swk_reader",
+			mockSetup: func() {
+				mockApiKeyRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:   "Read key can read",
+			method: http.MethodGet,
+			key:    "swk_reader",
+			mockSetup: func() {
+				mockApiKeyRepo.EXPECT().GetByHash(gomock.Any(), models.HashApiKey("swk_reader")).Return(readKey, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedActor:  "apikey:reader",
+		},
+		{
+			name:   "Read key cannot write",
+			method: http.MethodPost,
+			key:    "swk_reader",
+			mockSetup: func() {
+				mockApiKeyRepo.EXPECT().GetByHash(gomock.Any(), models.HashApiKey("swk_reader")).Return(readKey, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "Write key can delete",
+			method: http.MethodDelete,
+			key:    "swk_writer",
+			mockSetup: func() {
+				mockApiKeyRepo.EXPECT().GetByHash(gomock.Any(), models.HashApiKey("swk_writer")).Return(writeKey, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedActor:  "apikey:writer",
+		},
+		{
+			name:   "Write key can read",
+			method: http.MethodGet,
+			key:    "swk_writer",
+			mockSetup: func() {
+				mockApiKeyRepo.EXPECT().GetByHash(gomock.Any(), models.HashApiKey("swk_writer")).Return(writeKey, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedActor:  "apikey:writer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			var actor string
+			router := gin.New()
+			router.Use(authenticate(apiKeyAuthenticator{apiKeyRepo: mockApiKeyRepo}))
+			router.Handle(tt.method, "/", func(c *gin.Context) {
+				actor = models.ActorFrom(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest(tt.method, "/", nil)
+			if tt.key != "" {
+				req.Header.Set(apiKeyHeader, tt.key)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedActor, actor)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"awesomeProject/health"
+	"context"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// readinessTimeout bounds each /readyz probe, so a hanging database fails it
+// instead of stalling the orchestrator.
+const readinessTimeout = 2 * time.Second
+
+// liveness answers as long as the process can serve requests.
+func liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusOK})
+}
+
+// readiness runs checks and answers 503 Service Unavailable unless all pass.
+func readiness(checks map[string]health.Check) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+		defer cancel()
+
+		report := health.Run(ctx, checks)
+		status := http.StatusOK
+		if report.Status != health.StatusOK {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, report)
+	}
+}
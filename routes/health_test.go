@@ -0,0 +1,100 @@
+package routes
+
+import (
+	"awesomeProject/configs"
+	"awesomeProject/controllers"
+	"awesomeProject/health"
+	"awesomeProject/logging"
+	"bytes"
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var importTask health.Task
+	var out bytes.Buffer
+	router := SetupRouter(&controllers.Controller{},
+		WithLogger(logging.New(&out, slog.LevelInfo)),
+		WithApiKeys(nil),
+		WithRateLimit(configs.Default().RateLimit),
+		WithHealthChecks(map[string]health.Check{
+			"database": func(context.Context) error { return nil },
+			"import":   importTask.Check,
+		}),
+	)
+
+	tests := []struct {
+		name           string
+		path           string
+		setup          func()
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Live without credentials",
+			path:           "/healthz",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status": "ok"}`,
+		},
+		{
+			name:           "Not ready during the import",
+			path:           "/readyz",
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"status": "unavailable", "checks": {"database": {"status": "ok"}, "import": {"status": "unavailable", "error": "not finished"}}}`,
+		},
+		{
+			name:           "Ready after the import",
+			path:           "/readyz",
+			setup:          importTask.Finish,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status": "ok", "checks": {"database": {"status": "ok"}, "import": {"status": "ok"}}}`,
+		},
+		{
+			name:           "Not ready after a failed import",
+			path:           "/readyz",
+			setup:          func() { importTask.Fail(errors.New("opening data.csv: no such file or directory")) },
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"status": "unavailable", "checks": {"database": {"status": "ok"}, "import": {"status": "unavailable", "error": "failed: opening data.csv: no such file or directory"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+
+	assert.Empty(t, out.String())
+}
+
+func TestReadiness_Timeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/readyz", readiness(map[string]health.Check{
+		"database": func(ctx context.Context) error {
+			<-ctx.Done()
+			return errors.New("ping timed out")
+		},
+	}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
@@ -0,0 +1,185 @@
+package routes
+
+import (
+	"awesomeProject/configs"
+	"awesomeProject/customErrors"
+	"awesomeProject/models"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+const principalKindJWT = "jwt"
+
+// jwtLeeway tolerates clock skew between the token issuer and this server.
+const jwtLeeway = 30 * time.Second
+
+type jwtAuthenticator struct {
+	config  configs.JWTConfig
+	secret  []byte
+	rsaKeys map[string]*rsa.PublicKey
+	parser  *jwt.Parser
+}
+
+// NewJWTAuthenticator accepts bearer tokens signed with config.Secret (HS256)
+// or a key from config.JWKSFile (RS256), which is read once here.
+func NewJWTAuthenticator(config configs.JWTConfig) (Authenticator, error) {
+	authenticator := &jwtAuthenticator{config: config}
+
+	var methods []string
+	if config.Secret != "" {
+		authenticator.secret = []byte(config.Secret)
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if config.JWKSFile != "" {
+		keys, err := loadJWKS(config.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticator.rsaKeys = keys
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("jwt authentication needs a secret or a JWKS file")
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(jwtLeeway),
+	}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+	authenticator.parser = jwt.NewParser(options...)
+
+	return authenticator, nil
+}
+
+func (authenticator *jwtAuthenticator) Authenticate(r *http.Request) (models.Principal, error) {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return models.Principal{}, ErrNoCredentials
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := authenticator.parser.ParseWithClaims(strings.TrimSpace(token), claims, authenticator.key)
+	if err != nil {
+		return models.Principal{}, customErrors.ErrUnauthorized
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return models.Principal{}, customErrors.ErrUnauthorized
+	}
+
+	return models.Principal{Kind: principalKindJWT, Name: subject, Scope: authenticator.scope(claims)}, nil
+}
+
+// key picks the verification key for the token's algorithm. RS256 tokens name
+// their key with "kid", which may be left out when the JWKS has a single key.
+func (authenticator *jwtAuthenticator) key(token *jwt.Token) (any, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return authenticator.secret, nil
+	case jwt.SigningMethodRS256.Alg():
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := authenticator.rsaKeys[kid]; ok {
+			return key, nil
+		}
+		if kid == "" && len(authenticator.rsaKeys) == 1 {
+			for _, key := range authenticator.rsaKeys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+// scope maps the scope claim, either a space-separated string or a list, to
+// models.ScopeWrite or models.ScopeRead. Tokens without either get no scope.
+func (authenticator *jwtAuthenticator) scope(claims jwt.MapClaims) string {
+	var granted []string
+	switch value := claims[authenticator.config.ScopeClaim].(type) {
+	case string:
+		granted = strings.Fields(value)
+	case []any:
+		for _, item := range value {
+			if scope, ok := item.(string); ok {
+				granted = append(granted, scope)
+			}
+		}
+	}
+
+	switch {
+	case slices.Contains(granted, authenticator.config.WriteScope):
+		return models.ScopeWrite
+	case slices.Contains(granted, authenticator.config.ReadScope):
+		return models.ScopeRead
+	default:
+		return ""
+	}
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// loadJWKS reads the RSA signing keys of a JWKS file by key id. Keys of other
+// types or uses are skipped.
+func loadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading JWKS file: %w", err)
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(content, &jwks); err != nil {
+		return nil, fmt.Errorf("parsing JWKS file %s: %w", path, err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, key := range jwks.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") || (key.Alg != "" && key.Alg != jwt.SigningMethodRS256.Alg()) {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("JWKS key %q has an invalid modulus: %w", key.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("JWKS key %q has an invalid exponent: %w", key.Kid, err)
+		}
+		keys[key.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS file %s has no RS256 signing keys", path)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,214 @@
+package routes
+
+import (
+	"awesomeProject/configs"
+	"awesomeProject/models"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testJWTSecret = "0123456789abcdef0123456789abcdef"
+
+func writeJWKS(t *testing.T, keys map[string]*rsa.PublicKey) string {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	for kid, key := range keys {
+		jwks.Keys = append(jwks.Keys, jsonWebKey{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	content, err := json.Marshal(jwks)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(path, content, 0o644))
+	return path
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	assert.NoError(t, err)
+	return signed
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	config := configs.Default().Auth.JWT
+	config.Secret = testJWTSecret
+	config.JWKSFile = writeJWKS(t, map[string]*rsa.PublicKey{"main": &rsaKey.PublicKey})
+	config.Issuer = "https://issuer.test"
+	config.Audience = "swift-codes"
+
+	authenticator, err := NewJWTAuthenticator(config)
+	assert.NoError(t, err)
+
+	claims := func(scope any, expiresIn time.Duration) jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":   "service-a",
+			"iss":   "https://issuer.test",
+			"aud":   "swift-codes",
+			"exp":   time.Now().Add(expiresIn).Unix(),
+			"scope": scope,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		authorization  string
+		expectedStatus int
+		expectedActor  string
+	}{
+		{
+			name:           "No bearer token",
+			method:         http.MethodGet,
+			authorization:  "Basic dXNlcjpwYXNz",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "HS256 read token can read",
+			method:         http.MethodGet,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), "", claims("openid swift-codes:read", time.Minute)),
+			expectedStatus: http.StatusOK,
+			expectedActor:  "jwt:service-a",
+		},
+		{
+			name:           "HS256 read token cannot write",
+			method:         http.MethodPost,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), "", claims("swift-codes:read", time.Minute)),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "RS256 write token as a list can write",
+			method:         http.MethodDelete,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodRS256, rsaKey, "main", claims([]string{"swift-codes:write"}, time.Minute)),
+			expectedStatus: http.StatusOK,
+			expectedActor:  "jwt:service-a",
+		},
+		{
+			name:           "RS256 token without kid uses the only key",
+			method:         http.MethodGet,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodRS256, rsaKey, "", claims("swift-codes:write", time.Minute)),
+			expectedStatus: http.StatusOK,
+			expectedActor:  "jwt:service-a",
+		},
+		{
+			name:           "Token without a swift-codes scope",
+			method:         http.MethodGet,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), "", claims("openid", time.Minute)),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Expired token",
+			method:         http.MethodGet,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), "", claims("swift-codes:read", -time.Hour)),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Wrong secret",
+			method:         http.MethodGet,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("fedcba9876543210fedcba9876543210"), "", claims("swift-codes:read", time.Minute)),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Unknown RSA key",
+			method:         http.MethodGet,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodRS256, otherKey, "other", claims("swift-codes:read", time.Minute)),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Wrong issuer",
+			method:         http.MethodGet,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), "", jwt.MapClaims{"sub": "service-a", "iss": "https://other.test", "aud": "swift-codes", "exp": time.Now().Add(time.Minute).Unix(), "scope": "swift-codes:read"}),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Unsigned token",
+			method:         http.MethodGet,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", claims("swift-codes:write", time.Minute)),
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actor string
+			router := gin.New()
+			router.Use(authenticate(authenticator))
+			router.Handle(tt.method, "/", func(c *gin.Context) {
+				actor = models.ActorFrom(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest(tt.method, "/", nil)
+			req.Header.Set("Authorization", tt.authorization)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedActor, actor)
+		})
+	}
+}
+
+func TestNewJWTAuthenticator_Errors(t *testing.T) {
+	emptyJWKS := filepath.Join(t.TempDir(), "empty.json")
+	assert.NoError(t, os.WriteFile(emptyJWKS, []byte(`{"keys":[{"kty":"EC","kid":"ec"}]}`), 0o644))
+
+	tests := []struct {
+		name    string
+		config  configs.JWTConfig
+		wantErr string
+	}{
+		{
+			name:    "No keys configured",
+			config:  configs.JWTConfig{},
+			wantErr: "needs a secret or a JWKS file",
+		},
+		{
+			name:    "Missing JWKS file",
+			config:  configs.JWTConfig{JWKSFile: filepath.Join(t.TempDir(), "missing.json")},
+			wantErr: "reading JWKS file",
+		},
+		{
+			name:    "JWKS without RSA keys",
+			config:  configs.JWTConfig{JWKSFile: emptyJWKS},
+			wantErr: "has no RS256 signing keys",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewJWTAuthenticator(tt.config)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
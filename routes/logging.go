@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"awesomeProject/customErrors"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+)
+
+// logRequests logs every request once it is handled, at warn level for
+// server errors, and the errors handlers attached to the gin context.
+func logRequests(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("clientIp", c.ClientIP()),
+			slog.Int("bytes", c.Writer.Size()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+		logger.LogAttrs(c.Request.Context(), level, "request", attrs...)
+	}
+}
+
+// recoverPanics answers a panicking request with 500 Internal Server Error
+// and logs the panic instead of crashing the server.
+func recoverPanics(logger *slog.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(c *gin.Context, err any) {
+		logger.ErrorContext(c.Request.Context(), "request panicked", "error", err, "stack", string(debug.Stack()))
+		customErrors.ErrUnknown.Send(c)
+		c.Abort()
+	})
+}
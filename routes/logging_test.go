@@ -0,0 +1,78 @@
+package routes
+
+import (
+	"awesomeProject/controllers"
+	"awesomeProject/logging"
+	"awesomeProject/repositories"
+	"awesomeProject/services"
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLogRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var out bytes.Buffer
+	logger := logging.New(&out, slog.LevelInfo)
+	controller := &controllers.Controller{
+		SwiftRepo:    repositories.NewSwiftRepoMemory(),
+		SwiftService: &services.SwiftServiceDefault{},
+		Logger:       logger,
+	}
+	router := SetupRouter(controller, WithLogger(logger))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/swift-codes/AAAAAAAAXXX", nil)
+	req.Header.Set(requestIDHeader, "request-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"type": "urn:swift-codes:problem:swift-not-found", "title": "Not Found", "status": 404,
+		"detail": "Swift not found", "code": "SWIFT_NOT_FOUND", "requestId": "request-1"}`, w.Body.String())
+
+	var record map[string]any
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	assert.Equal(t, "request", record["msg"])
+	assert.Equal(t, "request-1", record["requestId"])
+	assert.Equal(t, "/v1/swift-codes/:swiftCode", record["route"])
+	assert.Equal(t, float64(http.StatusNotFound), record["status"])
+}
+
+func TestRecoverPanics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var out bytes.Buffer
+	logger := logging.New(&out, slog.LevelInfo)
+	router := gin.New()
+	router.Use(requestID(), logRequests(logger), recoverPanics(logger))
+	router.GET("/", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(requestIDHeader, "request-2")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"type": "urn:swift-codes:problem:internal-error", "title": "Internal Server Error", "status": 500,
+		"detail": "Something went wrong", "code": "INTERNAL_ERROR", "requestId": "request-2"}`, w.Body.String())
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+
+	var panicked, request map[string]any
+	assert.NoError(t, json.Unmarshal(lines[0], &panicked))
+	assert.NoError(t, json.Unmarshal(lines[1], &request))
+	assert.Equal(t, "request panicked", panicked["msg"])
+	assert.Equal(t, "boom", panicked["error"])
+	assert.Equal(t, "request-2", panicked["requestId"])
+	assert.Equal(t, "WARN", request["level"])
+	assert.Equal(t, float64(http.StatusInternalServerError), request["status"])
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"awesomeProject/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"strconv"
+	"time"
+)
+
+// unmatchedRoute labels requests no route matched, so arbitrary paths don't
+// each get their own series.
+const unmatchedRoute = "unmatched"
+
+// instrument records the duration and status code of every request by its
+// route pattern.
+func instrument() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+		metrics.HTTPRequestsTotal.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+	}
+}
+
+func metricsHandler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
+}
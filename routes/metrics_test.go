@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"awesomeProject/configs"
+	"awesomeProject/controllers"
+	"awesomeProject/repositories"
+	"awesomeProject/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	controller := &controllers.Controller{
+		SwiftRepo:    repositories.NewSwiftRepoMemory(),
+		SwiftService: &services.SwiftServiceDefault{},
+	}
+	router := SetupRouter(controller, WithMetrics(configs.MetricsConfig{Enabled: true, Path: "/metrics"}))
+
+	for _, path := range []string{"/v1/swift-codes/AAAAAAAAXXX", "/v1/swift-codes/BBBBBBBBXXX", "/unknown/path"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	body, err := io.ReadAll(w.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `swift_codes_http_requests_total{method="GET",route="/v1/swift-codes/:swiftCode",status="404"} 2`)
+	assert.Contains(t, string(body), `swift_codes_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, string(body), `swift_codes_http_request_duration_seconds_count{method="GET",route="/v1/swift-codes/:swiftCode"} 2`)
+	assert.Contains(t, string(body), "go_goroutines")
+}
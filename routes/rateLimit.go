@@ -0,0 +1,134 @@
+package routes
+
+import (
+	"awesomeProject/configs"
+	"awesomeProject/customErrors"
+	"awesomeProject/models"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter keeps a token bucket per client. Buckets that have refilled
+// completely are dropped, since a new bucket starts full anyway.
+type rateLimiter struct {
+	limit configs.RateLimit
+	now   func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func newRateLimiter(limit configs.RateLimit) *rateLimiter {
+	return &rateLimiter{
+		limit:   limit,
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// refillTime is how long an empty bucket takes to fill up.
+func (limiter *rateLimiter) refillTime() time.Duration {
+	return time.Duration(float64(limiter.limit.Burst) / limiter.limit.Rate * float64(time.Second))
+}
+
+// take removes a token from the bucket of key if it has one, and returns the
+// tokens left.
+func (limiter *rateLimiter) take(key string) (allowed bool, remaining float64) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := limiter.now()
+	limiter.sweep(now)
+
+	b, ok := limiter.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limiter.limit.Burst), last: now}
+		limiter.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(limiter.limit.Burst), b.tokens+now.Sub(b.last).Seconds()*limiter.limit.Rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		return false, b.tokens
+	}
+	b.tokens--
+	return true, b.tokens
+}
+
+func (limiter *rateLimiter) sweep(now time.Time) {
+	refillTime := limiter.refillTime()
+	if now.Sub(limiter.lastSweep) < refillTime {
+		return
+	}
+	for key, b := range limiter.buckets {
+		if now.Sub(b.last) >= refillTime {
+			delete(limiter.buckets, key)
+		}
+	}
+	limiter.lastSweep = now
+}
+
+// secondsUntil is how long it takes to refill from tokens to target, rounded up.
+func (limiter *rateLimiter) secondsUntil(tokens, target float64) int {
+	return int(math.Ceil(math.Max(0, target-tokens) / limiter.limit.Rate))
+}
+
+// rateLimitKey identifies the client: the authenticated principal if there is
+// one, otherwise the client IP.
+func rateLimitKey(c *gin.Context) string {
+	if principal, ok := models.PrincipalFrom(c.Request.Context()); ok {
+		return principal.Kind + ":" + principal.Name
+	}
+	return clientIPKey(c)
+}
+
+func clientIPKey(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// rateLimit rejects requests with 429 once a client has used up its bucket,
+// and describes the limit in RateLimit-* headers.
+func rateLimit(limit configs.RateLimit) gin.HandlerFunc {
+	return newRateLimiter(limit).handler(rateLimitKey)
+}
+
+// rateLimitIP is rateLimit by client IP alone, for requests that are not
+// authenticated yet.
+func rateLimitIP(limit configs.RateLimit) gin.HandlerFunc {
+	return newRateLimiter(limit).handler(clientIPKey)
+}
+
+func (limiter *rateLimiter) handler(key func(c *gin.Context) string) gin.HandlerFunc {
+	limit := limiter.limit
+	policy := fmt.Sprintf("%d;w=%d", limit.Burst, int(math.Ceil(limiter.refillTime().Seconds())))
+
+	return func(c *gin.Context) {
+		allowed, remaining := limiter.take(key(c))
+
+		c.Header("RateLimit-Policy", policy)
+		c.Header("RateLimit-Limit", strconv.Itoa(limit.Burst))
+		c.Header("RateLimit-Remaining", strconv.Itoa(int(remaining)))
+
+		if !allowed {
+			retryAfter := strconv.Itoa(limiter.secondsUntil(remaining, 1))
+			c.Header("RateLimit-Reset", retryAfter)
+			c.Header("Retry-After", retryAfter)
+			abort(c, customErrors.ErrTooManyRequests)
+			return
+		}
+
+		c.Header("RateLimit-Reset", strconv.Itoa(limiter.secondsUntil(remaining, float64(limit.Burst))))
+		c.Next()
+	}
+}
@@ -0,0 +1,160 @@
+package routes
+
+import (
+	"awesomeProject/configs"
+	"awesomeProject/controllers"
+	"awesomeProject/mocks"
+	"awesomeProject/models"
+	"awesomeProject/repositories"
+	"awesomeProject/services"
+	"database/sql"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(configs.RateLimit{Rate: 0.5, Burst: 2})
+	limiter.now = func() time.Time { return now }
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if name := c.GetHeader("X-Principal"); name != "" {
+			principal := models.Principal{Kind: principalKindApiKey, Name: name, Scope: models.ScopeRead}
+			c.Request = c.Request.WithContext(models.WithPrincipal(c.Request.Context(), principal))
+		}
+	})
+	router.Use(limiter.handler(rateLimitKey))
+	router.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name               string
+		advance            time.Duration
+		remoteAddr         string
+		principal          string
+		expectedStatus     int
+		expectedRemaining  string
+		expectedReset      string
+		expectedRetryAfter string
+	}{
+		{
+			name:              "First request",
+			remoteAddr:        "10.0.0.1:1234",
+			expectedStatus:    http.StatusOK,
+			expectedRemaining: "1",
+			expectedReset:     "2",
+		},
+		{
+			name:              "Second request empties the bucket",
+			remoteAddr:        "10.0.0.1:1234",
+			expectedStatus:    http.StatusOK,
+			expectedRemaining: "0",
+			expectedReset:     "4",
+		},
+		{
+			name:               "Third request is limited",
+			remoteAddr:         "10.0.0.1:1234",
+			expectedStatus:     http.StatusTooManyRequests,
+			expectedRemaining:  "0",
+			expectedReset:      "2",
+			expectedRetryAfter: "2",
+		},
+		{
+			name:              "Other client has its own bucket",
+			remoteAddr:        "10.0.0.2:1234",
+			expectedStatus:    http.StatusOK,
+			expectedRemaining: "1",
+			expectedReset:     "2",
+		},
+		{
+			name:              "Principal is limited apart from its IP",
+			remoteAddr:        "10.0.0.1:1234",
+			principal:         "reader",
+			expectedStatus:    http.StatusOK,
+			expectedRemaining: "1",
+			expectedReset:     "2",
+		},
+		{
+			name:              "Bucket refills over time",
+			advance:           2 * time.Second,
+			remoteAddr:        "10.0.0.1:1234",
+			expectedStatus:    http.StatusOK,
+			expectedRemaining: "0",
+			expectedReset:     "4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.advance)
+
+			req, _ := http.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.principal != "" {
+				req.Header.Set("X-Principal", tt.principal)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+			assert.Equal(t, "2;w=4", w.Header().Get("RateLimit-Policy"))
+			assert.Equal(t, tt.expectedRemaining, w.Header().Get("RateLimit-Remaining"))
+			assert.Equal(t, tt.expectedReset, w.Header().Get("RateLimit-Reset"))
+			assert.Equal(t, tt.expectedRetryAfter, w.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func TestRateLimiter_Sweep(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(configs.RateLimit{Rate: 1, Burst: 5})
+	limiter.now = func() time.Time { return now }
+
+	limiter.take("ip:10.0.0.1")
+	now = now.Add(3 * time.Second)
+	limiter.take("ip:10.0.0.2")
+	assert.Len(t, limiter.buckets, 2)
+
+	now = now.Add(3 * time.Second)
+	limiter.take("ip:10.0.0.3")
+	assert.Len(t, limiter.buckets, 2)
+	assert.NotContains(t, limiter.buckets, "ip:10.0.0.1")
+}
+
+func TestRateLimit_BeforeAuthentication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockApiKeyRepo := mocks.NewMockApiKeyRepo(ctrl)
+	// Only the requests the IP limit lets through look up their key.
+	mockApiKeyRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows).Times(2)
+
+	controller := &controllers.Controller{
+		SwiftRepo:    repositories.NewSwiftRepoMemory(),
+		SwiftService: &services.SwiftServiceDefault{},
+	}
+	limits := configs.Default().RateLimit
+	limits.IP = configs.RateLimit{Rate: 0.01, Burst: 2}
+	router := SetupRouter(controller, WithApiKeys(mockApiKeyRepo), WithRateLimit(limits))
+
+	for _, expectedStatus := range []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests, http.StatusTooManyRequests} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/swift-codes/AAAAAAAAXXX", nil)
+		req.Header.Set(apiKeyHeader, "swk_guess")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, expectedStatus, w.Code)
+	}
+}
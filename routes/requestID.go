@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"awesomeProject/models"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client supplied IDs, which end up in the audit log.
+const maxRequestIDLength = 128
+
+// requestID tags every request with the client's X-Request-ID, or a new one,
+// and echoes it in the response.
+func requestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = newRequestID()
+		}
+
+		c.Header(requestIDHeader, id)
+		c.Request = c.Request.WithContext(models.WithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -1,15 +1,151 @@
 package routes
 
 import (
+	"awesomeProject/configs"
 	"awesomeProject/controllers"
+	"awesomeProject/customErrors"
+	"awesomeProject/health"
+	"awesomeProject/logging"
+	"awesomeProject/repositories"
 	"awesomeProject/routes/v1"
 	"github.com/gin-gonic/gin"
+	"log/slog"
 )
 
-func SetupRouter(swiftController *controllers.Controller) *gin.Engine {
-	router := gin.Default()
+type routerOptions struct {
+	features       configs.FeaturesConfig
+	authenticators []Authenticator
+	rateLimit      configs.RateLimitConfig
+	trustedProxies []string
+	metrics        configs.MetricsConfig
+	tracing        bool
+	logger         *slog.Logger
+	healthChecks   map[string]health.Check
+}
+
+type Option func(*routerOptions)
+
+// WithFeatures registers only the optional endpoints enabled in features.
+func WithFeatures(features configs.FeaturesConfig) Option {
+	return func(options *routerOptions) {
+		options.features = features
+	}
+}
+
+// WithApiKeys accepts the API keys stored in apiKeyRepo.
+func WithApiKeys(apiKeyRepo repositories.ApiKeyRepo) Option {
+	return WithAuthenticator(apiKeyAuthenticator{apiKeyRepo: apiKeyRepo})
+}
+
+// WithAuthenticator adds an authenticator. With any authenticator set, every
+// endpoint requires credentials one of them accepts with the right scope.
+func WithAuthenticator(authenticator Authenticator) Option {
+	return func(options *routerOptions) {
+		options.authenticators = append(options.authenticators, authenticator)
+	}
+}
+
+// WithRateLimit limits the requests of each client in every route group.
+func WithRateLimit(rateLimit configs.RateLimitConfig) Option {
+	return func(options *routerOptions) {
+		options.rateLimit = rateLimit
+	}
+}
+
+// WithTrustedProxies trusts X-Forwarded-For from the given addresses when
+// working out the client IP. By default no proxy is trusted.
+func WithTrustedProxies(trustedProxies []string) Option {
+	return func(options *routerOptions) {
+		options.trustedProxies = trustedProxies
+	}
+}
+
+// WithMetrics instruments every request and serves the metrics on
+// metrics.Path.
+func WithMetrics(metrics configs.MetricsConfig) Option {
+	return func(options *routerOptions) {
+		options.metrics = metrics
+	}
+}
+
+// WithTracing starts a span for every request with the global tracer provider.
+func WithTracing(tracing configs.TracingConfig) Option {
+	return func(options *routerOptions) {
+		options.tracing = tracing.Enabled
+	}
+}
+
+// WithLogger logs every request and panic to logger instead of slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(options *routerOptions) {
+		options.logger = logger
+	}
+}
+
+// WithHealthChecks makes /readyz answer 503 Service Unavailable until every
+// check passes. Without checks the service is ready as soon as it is live.
+func WithHealthChecks(checks map[string]health.Check) Option {
+	return func(options *routerOptions) {
+		options.healthChecks = checks
+	}
+}
+
+func SetupRouter(swiftController *controllers.Controller, opts ...Option) *gin.Engine {
+	options := routerOptions{
+		features: configs.Default().Features,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	logger := logging.OrDefault(options.logger)
+
+	router := gin.New()
+	err := router.SetTrustedProxies(options.trustedProxies)
+	if err != nil {
+		panic(err)
+	}
+	// Unknown paths and methods get problems like every other error. gin
+	// sets the Allow header of a 405 itself.
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(func(c *gin.Context) {
+		customErrors.ErrNotFound.Send(c)
+	})
+	router.NoMethod(func(c *gin.Context) {
+		customErrors.ErrMethodNotAllowed.Send(c)
+	})
+	router.Use(requestID())
+	// Probes are registered before the other middleware, so they are not
+	// logged, traced or counted.
+	router.GET("/healthz", liveness)
+	router.GET("/readyz", readiness(options.healthChecks))
+	if options.tracing {
+		router.Use(traceRequests())
+	}
+	if options.metrics.Enabled {
+		router.Use(instrument())
+	}
+	router.Use(logRequests(logger), recoverPanics(logger))
+	if options.metrics.Enabled {
+		router.GET(options.metrics.Path, metricsHandler())
+	}
+
+	group := router.Group("/v1/swift-codes")
+	// Limiting by IP first keeps clients from trying credentials without limit.
+	if options.rateLimit.Enabled {
+		group.Use(rateLimitIP(options.rateLimit.IP))
+	}
+	if len(options.authenticators) > 0 {
+		group.Use(authenticate(options.authenticators...))
+	}
 
-	v1.SetupGroup(router.Group("/v1/swift-codes"), swiftController)
+	var middleware v1.Middleware
+	if options.rateLimit.Enabled {
+		middleware.Read = append(middleware.Read, rateLimit(options.rateLimit.Read))
+		middleware.List = append(middleware.List, rateLimit(options.rateLimit.List))
+		middleware.Write = append(middleware.Write, rateLimit(options.rateLimit.Write))
+	}
+	v1.SetupGroup(group, swiftController, options.features, middleware)
 
 	return router
 }
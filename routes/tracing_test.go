@@ -0,0 +1,61 @@
+package routes
+
+import (
+	"awesomeProject/configs"
+	"awesomeProject/controllers"
+	"awesomeProject/repositories"
+	"awesomeProject/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTraceRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	}()
+
+	controller := &controllers.Controller{
+		SwiftRepo:    repositories.NewSwiftRepoMemory(),
+		SwiftService: services.NewSwiftServiceTraced(&services.SwiftServiceDefault{}),
+	}
+	router := SetupRouter(controller, WithTracing(configs.TracingConfig{Enabled: true}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/swift-codes/AAAAAAAAXXX", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	spans := recorder.Ended()
+	names := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range spans {
+		names[span.Name()] = span
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+	}
+
+	server, ok := names["GET /v1/swift-codes/:swiftCode"]
+	assert.True(t, ok)
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+	assert.Equal(t, codes.Unset, server.Status().Code)
+
+	details, ok := names["SwiftService.GetSwiftDetails"]
+	assert.True(t, ok)
+	assert.Equal(t, server.SpanContext().SpanID(), details.Parent().SpanID())
+	assert.Equal(t, codes.Unset, details.Status().Code)
+	assert.Len(t, details.Events(), 1)
+}
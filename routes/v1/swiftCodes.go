@@ -1,14 +1,40 @@
 package v1
 
 import (
+	"awesomeProject/configs"
 	"awesomeProject/controllers"
 	"github.com/gin-gonic/gin"
 )
 
-func SetupGroup(group *gin.RouterGroup, controller *controllers.Controller) {
+// Middleware is added in front of the endpoints of each route group.
+type Middleware struct {
+	// Read covers single swift codes and their history.
+	Read []gin.HandlerFunc
+	// List covers whole countries, search and export.
+	List  []gin.HandlerFunc
+	Write []gin.HandlerFunc
+}
+
+func SetupGroup(group *gin.RouterGroup, controller *controllers.Controller, features configs.FeaturesConfig, middleware Middleware) {
+	read := group.Group("", middleware.Read...)
+	list := group.Group("", middleware.List...)
+	write := group.Group("", middleware.Write...)
 
-	group.POST("/", controller.AddSwift)
-	group.GET("/:swiftCode", controller.GetSwiftDetails)
-	group.DELETE("/:swiftCode", controller.DeleteSwift)
-	group.GET("/country/:countryIso2Code", controller.GetSwiftsDetailsByCountryIso2Code)
+	write.POST("/", controller.AddSwift)
+	if features.Bulk {
+		write.POST("/bulk", controller.AddSwifts)
+	}
+	if features.Search {
+		list.GET("/search", controller.Search)
+	}
+	if features.Export {
+		list.GET("/export", controller.Export)
+	}
+	read.GET("/:swiftCode", controller.GetSwiftDetails)
+	read.GET("/:swiftCode/history", controller.GetSwiftHistory)
+	write.PUT("/:swiftCode", controller.UpdateSwift)
+	write.PATCH("/:swiftCode", controller.PatchSwift)
+	write.DELETE("/:swiftCode", controller.DeleteSwift)
+	write.POST("/:swiftCode/restore", controller.RestoreSwift)
+	list.GET("/country/:countryIso2Code", controller.GetSwiftsDetailsByCountryIso2Code)
 }
@@ -0,0 +1,40 @@
+package services
+
+import "encoding/json"
+
+// applyMergePatch applies a JSON merge patch (RFC 7386) to a JSON document.
+func applyMergePatch(document []byte, patch []byte) ([]byte, error) {
+	var target interface{}
+	if err := json.Unmarshal(document, &target); err != nil {
+		return nil, err
+	}
+
+	var patchValue interface{}
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(mergePatchValue(target, patchValue))
+}
+
+func mergePatchValue(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatchValue(targetObject[key], value)
+	}
+
+	return targetObject
+}
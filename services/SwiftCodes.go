@@ -2,34 +2,80 @@ package services
 
 import (
 	"awesomeProject/customErrors"
+	"awesomeProject/logging"
 	"awesomeProject/models"
 	"awesomeProject/repositories"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"log/slog"
+	"slices"
 	"strings"
+	"time"
 )
 
 type SwiftService interface {
+	// GetSwiftDetails returns the branches of a headquarter or the parent of a
+	// branch; the other one is always nil.
 	GetSwiftDetails(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) (
 		swift *models.Swift,
 		branches []models.SwiftMini,
+		parent *models.BranchParent,
 		err error,
 	)
-	GetSwiftsDetailsByCountryIso2Code(ctx context.Context, countryIso2Code string, swiftRepo repositories.SwiftRepo) (
+	GetSwiftsDetailsByCountryIso2Code(ctx context.Context, countryIso2Code string, page models.PageRequest, swiftRepo repositories.SwiftRepo) (
 		countryName string,
 		swifts []models.SwiftMini,
+		nextCursor string,
 		err error,
 	)
+	Search(ctx context.Context, search models.SearchQuery, swiftRepo repositories.SwiftRepo) (
+		swifts []models.SwiftMini,
+		nextOffset int,
+		err error,
+	)
+	ExportSwifts(ctx context.Context, countryIso2Code string, swiftRepo repositories.SwiftRepo, write func(*models.Swift) error) error
 	AddSwift(ctx context.Context, swift *models.Swift, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) error
-	DeleteSwift(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) error
+	// AddSwifts adds many swift codes and returns one error per item (nil on
+	// success). In atomic mode nothing is added unless every item is valid.
+	AddSwifts(ctx context.Context, swifts []models.Swift, atomic bool, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) (
+		itemErrs []error,
+		err error,
+	)
+	// CheckSwifts returns the error that would keep each swift from being
+	// added by AddSwifts in atomic mode, without adding any of them.
+	CheckSwifts(ctx context.Context, swifts []models.Swift, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) []error
+	UpdateSwift(ctx context.Context, swiftCode string, swift *models.Swift, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) error
+	PatchSwift(ctx context.Context, swiftCode string, patch []byte, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) error
+	// DeleteSwift deletes a swift code. A non-empty ifMatch is an If-Match
+	// header that must match the current version of the code.
+	DeleteSwift(ctx context.Context, swiftCode string, ifMatch string, swiftRepo repositories.SwiftRepo) error
+	RestoreSwift(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) error
+	GetSwiftHistory(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) ([]models.SwiftAudit, error)
+	// GetCountryVersion returns the version of the data of a country. Read it
+	// before the data, so that the data is never older than the version.
+	GetCountryVersion(ctx context.Context, countryIso2Code string, swiftRepo repositories.SwiftRepo) (models.CountryVersion, error)
+	// GetSwiftVersion returns the version of the details of a swift code. Read
+	// it before the details, like GetCountryVersion.
+	GetSwiftVersion(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) (models.SwiftVersion, error)
 }
 
-type SwiftServiceDefault struct{}
+type SwiftServiceDefault struct {
+	// MissingHeadquarter is what happens when a branch is added before its
+	// headquarter, models.MissingHeadquarterFlag when empty.
+	MissingHeadquarter string
+	// HeadquarterDelete is what happens to the branches of a deleted
+	// headquarter, models.HeadquarterDeleteOrphan when empty.
+	HeadquarterDelete string
+	// Logger is slog.Default() when nil.
+	Logger *slog.Logger
+}
 
 func (s *SwiftServiceDefault) GetSwiftDetails(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) (
 	swift *models.Swift,
 	branches []models.SwiftMini,
+	parent *models.BranchParent,
 	err error,
 ) {
 	swift, err = swiftRepo.GetBySwiftCode(ctx, swiftCode)
@@ -41,7 +87,11 @@ func (s *SwiftServiceDefault) GetSwiftDetails(ctx context.Context, swiftCode str
 		return
 	}
 	if !models.IsSwiftCodeOfHeadquarter(swiftCode) {
-		return swift, nil, nil
+		parent, err = swiftRepo.GetBranchParent(ctx, swift.SwiftCode)
+		if err != nil {
+			return
+		}
+		return swift, nil, parent, nil
 	}
 
 	branches, err = swiftRepo.GetBranchesBySwiftCode(ctx, swiftCode)
@@ -49,22 +99,42 @@ func (s *SwiftServiceDefault) GetSwiftDetails(ctx context.Context, swiftCode str
 		return
 	}
 
-	return swift, branches, nil
+	return swift, branches, nil, nil
 }
 
-func (s *SwiftServiceDefault) GetSwiftsDetailsByCountryIso2Code(ctx context.Context, countryIso2Code string, swiftRepo repositories.SwiftRepo) (
+func (s *SwiftServiceDefault) GetSwiftsDetailsByCountryIso2Code(ctx context.Context, countryIso2Code string, page models.PageRequest, swiftRepo repositories.SwiftRepo) (
 	countryName string,
 	swifts []models.SwiftMini,
+	nextCursor string,
 	err error,
 ) {
-	swifts, err = swiftRepo.GetByCountryIso2Code(ctx, countryIso2Code)
+	if page.Limit <= 0 {
+		page.Limit = models.DefaultPageLimit
+	}
+	if page.SortBy == "" {
+		page.SortBy = models.SortBySwiftCode
+	}
+	page.After = strings.ToUpper(page.After)
+
+	limit := page.Limit
+	// One extra row tells whether there is a next page.
+	page.Limit++
+
+	swifts, err = swiftRepo.GetByCountryIso2Code(ctx, countryIso2Code, page)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, sql.ErrNoRows) && page.After != "" {
+			err = customErrors.ErrInvalidCursor
+		} else if errors.Is(err, sql.ErrNoRows) {
 			err = customErrors.ErrSwiftNotFound
 		}
 		return
 	}
 
+	if len(swifts) > limit {
+		swifts = swifts[:limit]
+		nextCursor = swifts[limit-1].SwiftCode
+	}
+
 	countryName, err = swiftRepo.GetCountryNameByIso2Code(ctx, countryIso2Code)
 	if err != nil {
 		return
@@ -73,6 +143,41 @@ func (s *SwiftServiceDefault) GetSwiftsDetailsByCountryIso2Code(ctx context.Cont
 	return
 }
 
+func (s *SwiftServiceDefault) Search(ctx context.Context, search models.SearchQuery, swiftRepo repositories.SwiftRepo) (
+	swifts []models.SwiftMini,
+	nextOffset int,
+	err error,
+) {
+	search.Query = strings.TrimSpace(search.Query)
+	if search.Query == "" {
+		err = customErrors.ErrSearchQueryRequired
+		return
+	}
+	if search.Limit <= 0 {
+		search.Limit = models.DefaultPageLimit
+	}
+	search.Country = strings.ToUpper(search.Country)
+
+	limit := search.Limit
+	search.Limit++
+
+	swifts, err = swiftRepo.Search(ctx, search)
+	if err != nil {
+		return
+	}
+
+	if len(swifts) > limit {
+		swifts = swifts[:limit]
+		nextOffset = search.Offset + limit
+	}
+
+	return
+}
+
+func (s *SwiftServiceDefault) ExportSwifts(ctx context.Context, countryIso2Code string, swiftRepo repositories.SwiftRepo, write func(*models.Swift) error) error {
+	return swiftRepo.ForEachSwift(ctx, strings.ToUpper(countryIso2Code), write)
+}
+
 func (s *SwiftServiceDefault) AddSwift(ctx context.Context, swift *models.Swift, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) error {
 	err := validate.Struct(swift)
 	if err != nil {
@@ -89,17 +194,278 @@ func (s *SwiftServiceDefault) AddSwift(ctx context.Context, swift *models.Swift,
 		return err
 	}
 
-	err = swiftRepo.AddSwift(ctx, swift)
-	if err != nil {
+	return swiftRepo.RunInTx(ctx, func(ctx context.Context, swiftRepo repositories.SwiftRepo) error {
+		return s.insertSwift(ctx, swift, swiftRepo)
+	})
+}
+
+// insertSwift links a branch to its headquarter, or a headquarter to the
+// branches added before it, adds the swift code and audits it. It must run
+// in a transaction.
+func (s *SwiftServiceDefault) insertSwift(ctx context.Context, swift *models.Swift, swiftRepo repositories.SwiftRepo) error {
+	swift.HeadquarterCode = ""
+
+	if models.IsSwiftCodeOfHeadquarter(swift.SwiftCode) {
+		if err := swiftRepo.AddSwift(ctx, swift); err != nil {
+			return err
+		}
+		if err := swiftRepo.LinkBranches(ctx, swift.SwiftCode); err != nil {
+			return err
+		}
+		return audit(ctx, models.AuditActionAdd, swift.SwiftCode, nil, swift, swiftRepo)
+	}
+
+	if err := s.linkToHeadquarter(ctx, swift, swiftRepo); err != nil {
+		return err
+	}
+	if err := swiftRepo.AddSwift(ctx, swift); err != nil {
 		return err
 	}
+	return audit(ctx, models.AuditActionAdd, swift.SwiftCode, nil, swift, swiftRepo)
+}
+
+// linkToHeadquarter sets the headquarter of a branch, applying the
+// MissingHeadquarter policy when it doesn't exist.
+func (s *SwiftServiceDefault) linkToHeadquarter(ctx context.Context, branch *models.Swift, swiftRepo repositories.SwiftRepo) error {
+	branch.HeadquarterCode = ""
 
+	headquarterCode := models.HeadquarterCodeOf(branch.SwiftCode)
+	_, err := swiftRepo.GetBySwiftCode(ctx, headquarterCode)
+	switch {
+	case err == nil:
+		branch.HeadquarterCode = headquarterCode
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	case s.MissingHeadquarter == models.MissingHeadquarterReject:
+		return customErrors.ErrHeadquarterNotFound
+	default:
+		logging.OrDefault(s.Logger).WarnContext(ctx, "branch added without its headquarter",
+			"swiftCode", branch.SwiftCode, "headquarterCode", headquarterCode)
+	}
 	return nil
 }
 
-func (s *SwiftServiceDefault) DeleteSwift(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) error {
+// audit records a change made by the actor of ctx. before and after are nil
+// when the swift code didn't exist before or doesn't exist after the change.
+func audit(ctx context.Context, action string, swiftCode string, before *models.Swift, after *models.Swift, swiftRepo repositories.SwiftRepo) error {
+	entry := &models.SwiftAudit{
+		SwiftCode: swiftCode,
+		Action:    action,
+		Actor:     models.ActorFrom(ctx),
+		RequestID: models.RequestIDFrom(ctx),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	var err error
+	if before != nil {
+		if entry.Before, err = json.Marshal(before); err != nil {
+			return err
+		}
+	}
+	if after != nil {
+		if entry.After, err = json.Marshal(after); err != nil {
+			return err
+		}
+	}
+
+	return swiftRepo.AddAudit(ctx, entry)
+}
+
+// headquartersFirst returns the indexes of swifts with headquarters before
+// branches, so a branch can be added in the same batch as its headquarter.
+func headquartersFirst(swifts []models.Swift) []int {
+	order := make([]int, 0, len(swifts))
+	for i := range swifts {
+		if models.IsSwiftCodeOfHeadquarter(swifts[i].SwiftCode) {
+			order = append(order, i)
+		}
+	}
+	for i := range swifts {
+		if !models.IsSwiftCodeOfHeadquarter(swifts[i].SwiftCode) {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+func (s *SwiftServiceDefault) AddSwifts(ctx context.Context, swifts []models.Swift, atomic bool, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) (
+	itemErrs []error,
+	err error,
+) {
+	itemErrs = make([]error, len(swifts))
+
+	order := headquartersFirst(swifts)
+
+	if !atomic {
+		for _, i := range order {
+			itemErrs[i] = s.AddSwift(ctx, &swifts[i], swiftRepo, validate)
+		}
+		return itemErrs, nil
+	}
+
+	itemErrs = s.CheckSwifts(ctx, swifts, swiftRepo, validate)
+	if abortBatch(itemErrs) {
+		return itemErrs, nil
+	}
+
+	err = swiftRepo.RunInTx(ctx, func(ctx context.Context, swiftRepo repositories.SwiftRepo) error {
+		for _, i := range order {
+			if err := s.insertSwift(ctx, &swifts[i], swiftRepo); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return itemErrs, nil
+}
+
+// abortBatch marks every item without an error as aborted if any item has
+// one, and reports whether it did.
+func abortBatch(itemErrs []error) bool {
+	if !slices.ContainsFunc(itemErrs, func(err error) bool { return err != nil }) {
+		return false
+	}
+	for i := range itemErrs {
+		if itemErrs[i] == nil {
+			itemErrs[i] = customErrors.ErrBulkAborted
+		}
+	}
+	return true
+}
+
+func (s *SwiftServiceDefault) CheckSwifts(ctx context.Context, swifts []models.Swift, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) []error {
+	itemErrs := make([]error, len(swifts))
+
+	batch := make(map[string]bool, len(swifts))
+	for i := range swifts {
+		batch[strings.ToUpper(swifts[i].SwiftCode)] = true
+	}
+
+	seen := make(map[string]bool, len(swifts))
+	for i := range swifts {
+		err := validate.Struct(&swifts[i])
+		if err == nil {
+			swifts[i].SwiftCode = strings.ToUpper(swifts[i].SwiftCode)
+			if seen[swifts[i].SwiftCode] {
+				err = customErrors.ErrSwiftCodeAlreadyExists
+			}
+			seen[swifts[i].SwiftCode] = true
+		}
+		if err == nil {
+			_, err = swiftRepo.GetBySwiftCode(ctx, swifts[i].SwiftCode)
+			if err == nil {
+				err = customErrors.ErrSwiftCodeAlreadyExists
+			} else if errors.Is(err, sql.ErrNoRows) {
+				err = nil
+			}
+		}
+		if err == nil && s.MissingHeadquarter == models.MissingHeadquarterReject && !models.IsSwiftCodeOfHeadquarter(swifts[i].SwiftCode) {
+			err = s.checkHeadquarterExists(ctx, models.HeadquarterCodeOf(swifts[i].SwiftCode), batch, swiftRepo)
+		}
+		itemErrs[i] = err
+	}
+
+	return itemErrs
+}
+
+func (s *SwiftServiceDefault) checkHeadquarterExists(ctx context.Context, headquarterCode string, batch map[string]bool, swiftRepo repositories.SwiftRepo) error {
+	if batch[headquarterCode] {
+		return nil
+	}
+	_, err := swiftRepo.GetBySwiftCode(ctx, headquarterCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return customErrors.ErrHeadquarterNotFound
+	}
+	return err
+}
+
+func (s *SwiftServiceDefault) UpdateSwift(ctx context.Context, swiftCode string, swift *models.Swift, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) error {
 	swiftCode = strings.ToUpper(swiftCode)
-	_, err := swiftRepo.GetBySwiftCode(ctx, swiftCode)
+	if swift.SwiftCode == "" {
+		swift.SwiftCode = swiftCode
+	}
+	if !strings.EqualFold(swift.SwiftCode, swiftCode) {
+		return customErrors.ErrSwiftCodeMismatch
+	}
+
+	err := validate.Struct(swift)
+	if err != nil {
+		return err
+	}
+
+	return swiftRepo.RunInTx(ctx, func(ctx context.Context, swiftRepo repositories.SwiftRepo) error {
+		current, err := swiftRepo.GetBySwiftCode(ctx, swiftCode)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return customErrors.ErrSwiftNotFound
+			}
+			return err
+		}
+		return replaceSwift(ctx, current, swift, swiftRepo)
+	})
+}
+
+// replaceSwift overwrites current with swift, keeping its headquarter, and
+// audits the change. It must run in a transaction.
+func replaceSwift(ctx context.Context, current *models.Swift, swift *models.Swift, swiftRepo repositories.SwiftRepo) error {
+	swift.HeadquarterCode = current.HeadquarterCode
+	if err := swiftRepo.UpdateSwift(ctx, swift); err != nil {
+		return err
+	}
+	return audit(ctx, models.AuditActionUpdate, swift.SwiftCode, current, swift, swiftRepo)
+}
+
+func (s *SwiftServiceDefault) PatchSwift(ctx context.Context, swiftCode string, patch []byte, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) error {
+	var patchObject map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &patchObject); err != nil {
+		return customErrors.ErrBadRequest
+	}
+
+	swiftCode = strings.ToUpper(swiftCode)
+	return swiftRepo.RunInTx(ctx, func(ctx context.Context, swiftRepo repositories.SwiftRepo) error {
+		current, err := swiftRepo.GetBySwiftCode(ctx, swiftCode)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return customErrors.ErrSwiftNotFound
+			}
+			return err
+		}
+
+		document, err := json.Marshal(current)
+		if err != nil {
+			return err
+		}
+
+		patched, err := applyMergePatch(document, patch)
+		if err != nil {
+			return err
+		}
+
+		var swift models.Swift
+		if err := json.Unmarshal(patched, &swift); err != nil {
+			return err
+		}
+
+		if !strings.EqualFold(swift.SwiftCode, swiftCode) {
+			return customErrors.ErrSwiftCodeMismatch
+		}
+
+		err = validate.Struct(&swift)
+		if err != nil {
+			return err
+		}
+
+		return replaceSwift(ctx, current, &swift, swiftRepo)
+	})
+}
+
+func (s *SwiftServiceDefault) DeleteSwift(ctx context.Context, swiftCode string, ifMatch string, swiftRepo repositories.SwiftRepo) error {
+	swiftCode = strings.ToUpper(swiftCode)
+	current, err := swiftRepo.GetBySwiftCode(ctx, swiftCode)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -108,7 +474,129 @@ func (s *SwiftServiceDefault) DeleteSwift(ctx context.Context, swiftCode string,
 		return err
 	}
 
-	err = swiftRepo.DeleteSwift(ctx, swiftCode)
+	return swiftRepo.RunInTx(ctx, func(ctx context.Context, swiftRepo repositories.SwiftRepo) error {
+		if ifMatch != "" {
+			// Every write to the bank bumps the version of its country, so
+			// holding that lock keeps the swift version from changing too.
+			if _, err := swiftRepo.LockCountryVersion(ctx, models.CountryOfSwiftCode(swiftCode)); err != nil {
+				return err
+			}
+			version, err := swiftRepo.GetSwiftVersion(ctx, swiftCode)
+			if err != nil {
+				return err
+			}
+			if !version.MatchesIfMatch(ifMatch) {
+				return customErrors.ErrPreconditionFailed
+			}
+		}
+		if models.IsSwiftCodeOfHeadquarter(swiftCode) {
+			if err := s.deleteBranchesOf(ctx, swiftCode, swiftRepo); err != nil {
+				return err
+			}
+		}
+		if err := swiftRepo.DeleteSwift(ctx, swiftCode); err != nil {
+			return err
+		}
+		return audit(ctx, models.AuditActionDelete, swiftCode, current, nil, swiftRepo)
+	})
+}
 
-	return err
+// RestoreSwift undeletes a swift code and links it again like a new one, since
+// its headquarter or branches may have changed while it was deleted.
+func (s *SwiftServiceDefault) RestoreSwift(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) error {
+	swiftCode = strings.ToUpper(swiftCode)
+	_, err := swiftRepo.GetBySwiftCode(ctx, swiftCode)
+	if err == nil {
+		return customErrors.ErrSwiftNotDeleted
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	return swiftRepo.RunInTx(ctx, func(ctx context.Context, swiftRepo repositories.SwiftRepo) error {
+		if err := swiftRepo.RestoreSwift(ctx, swiftCode); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return customErrors.ErrSwiftNotFound
+			}
+			return err
+		}
+
+		swift, err := swiftRepo.GetBySwiftCode(ctx, swiftCode)
+		if err != nil {
+			return err
+		}
+
+		if models.IsSwiftCodeOfHeadquarter(swiftCode) {
+			err = swiftRepo.LinkBranches(ctx, swiftCode)
+		} else if err = s.linkToHeadquarter(ctx, swift, swiftRepo); err == nil {
+			err = swiftRepo.UpdateSwift(ctx, swift)
+		}
+		if err != nil {
+			return err
+		}
+
+		return audit(ctx, models.AuditActionRestore, swiftCode, nil, swift, swiftRepo)
+	})
+}
+
+// deleteBranchesOf applies the HeadquarterDelete policy to the branches of a
+// headquarter about to be deleted.
+func (s *SwiftServiceDefault) deleteBranchesOf(ctx context.Context, headquarterCode string, swiftRepo repositories.SwiftRepo) error {
+	switch s.HeadquarterDelete {
+	case models.HeadquarterDeleteBlock:
+		branches, err := swiftRepo.GetBranchesBySwiftCode(ctx, headquarterCode)
+		if err != nil {
+			return err
+		}
+		if len(branches) > 0 {
+			return customErrors.ErrHeadquarterHasBranches
+		}
+		return nil
+	case models.HeadquarterDeleteCascade:
+		branches, err := swiftRepo.GetBranchesBySwiftCode(ctx, headquarterCode)
+		if err != nil {
+			return err
+		}
+		logging.OrDefault(s.Logger).InfoContext(ctx, "deleting branches with their headquarter",
+			"headquarterCode", headquarterCode, "branches", len(branches))
+		for _, branch := range branches {
+			before, err := swiftRepo.GetBySwiftCode(ctx, branch.SwiftCode)
+			if err != nil {
+				return err
+			}
+			if err := audit(ctx, models.AuditActionDelete, branch.SwiftCode, before, nil, swiftRepo); err != nil {
+				return err
+			}
+		}
+		return swiftRepo.DeleteBranches(ctx, headquarterCode)
+	default:
+		return swiftRepo.UnlinkBranches(ctx, headquarterCode)
+	}
+}
+
+// GetSwiftHistory returns the audit log of a swift code, which outlives it.
+// Codes loaded by the importer exist without any history.
+func (s *SwiftServiceDefault) GetSwiftHistory(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) ([]models.SwiftAudit, error) {
+	swiftCode = strings.ToUpper(swiftCode)
+	history, err := swiftRepo.GetAuditBySwiftCode(ctx, swiftCode)
+	if err != nil || len(history) > 0 {
+		return history, err
+	}
+
+	_, err = swiftRepo.GetBySwiftCode(ctx, swiftCode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, customErrors.ErrSwiftNotFound
+		}
+		return nil, err
+	}
+	return history, nil
+}
+
+func (s *SwiftServiceDefault) GetCountryVersion(ctx context.Context, countryIso2Code string, swiftRepo repositories.SwiftRepo) (models.CountryVersion, error) {
+	return swiftRepo.GetCountryVersion(ctx, strings.ToUpper(countryIso2Code))
+}
+
+func (s *SwiftServiceDefault) GetSwiftVersion(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) (models.SwiftVersion, error) {
+	return swiftRepo.GetSwiftVersion(ctx, strings.ToUpper(swiftCode))
 }
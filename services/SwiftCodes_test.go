@@ -4,12 +4,14 @@ import (
 	"awesomeProject/customErrors"
 	"awesomeProject/mocks"
 	"awesomeProject/models"
+	"awesomeProject/repositories"
 	"context"
 	"database/sql"
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"testing"
+	"time"
 )
 
 func TestGetSwiftDetails(t *testing.T) {
@@ -26,6 +28,7 @@ func TestGetSwiftDetails(t *testing.T) {
 		mockSetup    func()
 		wantSwift    *models.Swift
 		wantBranches []models.SwiftMini
+		wantParent   *models.BranchParent
 		wantErr      error
 	}{
 		{
@@ -91,6 +94,10 @@ func TestGetSwiftDetails(t *testing.T) {
 					CountryName:   "United States",
 					IsHeadquarter: false,
 				}, nil)
+				mockSwiftRepo.EXPECT().GetBranchParent(ctx, "ABCDEFGH001").Return(&models.BranchParent{
+					Headquarter:        &models.SwiftMini{SwiftCode: "ABCDEFGHXXX", IsHeadquarter: true},
+					SiblingBranchCount: 2,
+				}, nil)
 			},
 			wantSwift: &models.Swift{
 				SwiftCode:     "ABCDEFGH001",
@@ -101,7 +108,24 @@ func TestGetSwiftDetails(t *testing.T) {
 				IsHeadquarter: false,
 			},
 			wantBranches: nil,
-			wantErr:      nil,
+			wantParent: &models.BranchParent{
+				Headquarter:        &models.SwiftMini{SwiftCode: "ABCDEFGHXXX", IsHeadquarter: true},
+				SiblingBranchCount: 2,
+			},
+			wantErr: nil,
+		},
+		{
+			name:      "Error - Branch Parent",
+			swiftCode: "ABCDEFGH001",
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGH001").Return(&models.Swift{
+					SwiftCode: "ABCDEFGH001",
+				}, nil)
+				mockSwiftRepo.EXPECT().GetBranchParent(ctx, "ABCDEFGH001").Return(nil, errors.New("db error"))
+			},
+			wantSwift:    &models.Swift{SwiftCode: "ABCDEFGH001"},
+			wantBranches: nil,
+			wantErr:      errors.New("db error"),
 		},
 		{
 			name:      "Error - unknown error",
@@ -143,7 +167,7 @@ func TestGetSwiftDetails(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			gotSwift, gotBranches, err := service.GetSwiftDetails(ctx, tt.swiftCode, mockSwiftRepo)
+			gotSwift, gotBranches, gotParent, err := service.GetSwiftDetails(ctx, tt.swiftCode, mockSwiftRepo)
 			if tt.wantErr != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tt.wantErr, err)
@@ -152,6 +176,7 @@ func TestGetSwiftDetails(t *testing.T) {
 			}
 			assert.Equal(t, tt.wantSwift, gotSwift)
 			assert.Equal(t, tt.wantBranches, gotBranches)
+			assert.Equal(t, tt.wantParent, gotParent)
 		})
 	}
 }
@@ -163,20 +188,23 @@ func TestGetSwiftsDetailsByCountryIso2Code(t *testing.T) {
 	service := &SwiftServiceDefault{}
 	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
 	ctx := context.Background()
+	defaultPage := models.PageRequest{Limit: models.DefaultPageLimit + 1, SortBy: models.SortBySwiftCode}
 
 	tests := []struct {
 		name            string
 		countryIso2Code string
+		page            models.PageRequest
 		mockSetup       func()
 		wantCountryName string
 		wantSwifts      []models.SwiftMini
+		wantNextCursor  string
 		wantErr         error
 	}{
 		{
 			name:            "Success - Get Swifts by Country ISO2 Code",
 			countryIso2Code: "US",
 			mockSetup: func() {
-				mockSwiftRepo.EXPECT().GetByCountryIso2Code(ctx, "US").Return([]models.SwiftMini{
+				mockSwiftRepo.EXPECT().GetByCountryIso2Code(ctx, "US", defaultPage).Return([]models.SwiftMini{
 					{
 						SwiftCode:     "ABCDEFGHXXX",
 						BankName:      "Test Bank",
@@ -199,21 +227,58 @@ func TestGetSwiftsDetailsByCountryIso2Code(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name:            "Success - Next cursor when there are more rows",
+			countryIso2Code: "US",
+			page:            models.PageRequest{Limit: 1, After: "abcdefgh001", SortBy: models.SortByBankName},
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetByCountryIso2Code(ctx, "US", models.PageRequest{
+					Limit:  2,
+					After:  "ABCDEFGH001",
+					SortBy: models.SortByBankName,
+				}).Return([]models.SwiftMini{
+					{SwiftCode: "ABCDEFGHXXX", BankName: "A Bank"},
+					{SwiftCode: "BCDEFGHIXXX", BankName: "B Bank"},
+				}, nil)
+				mockSwiftRepo.EXPECT().GetCountryNameByIso2Code(ctx, "US").Return("United States", nil)
+			},
+			wantCountryName: "United States",
+			wantSwifts: []models.SwiftMini{
+				{SwiftCode: "ABCDEFGHXXX", BankName: "A Bank"},
+			},
+			wantNextCursor: "ABCDEFGHXXX",
+			wantErr:        nil,
+		},
 		{
 			name:            "Error - Country Not Found",
 			countryIso2Code: "XX",
 			mockSetup: func() {
-				mockSwiftRepo.EXPECT().GetByCountryIso2Code(ctx, "XX").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().GetByCountryIso2Code(ctx, "XX", defaultPage).Return(nil, sql.ErrNoRows)
 			},
 			wantCountryName: "",
 			wantSwifts:      nil,
 			wantErr:         customErrors.ErrSwiftNotFound,
 		},
+		{
+			name:            "Error - Unknown cursor",
+			countryIso2Code: "US",
+			page:            models.PageRequest{After: "zzzzzzzzxxx"},
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetByCountryIso2Code(ctx, "US", models.PageRequest{
+					Limit:  models.DefaultPageLimit + 1,
+					After:  "ZZZZZZZZXXX",
+					SortBy: models.SortBySwiftCode,
+				}).Return(nil, sql.ErrNoRows)
+			},
+			wantCountryName: "",
+			wantSwifts:      nil,
+			wantErr:         customErrors.ErrInvalidCursor,
+		},
 		{
 			name:            "Error - unknown error",
 			countryIso2Code: "XX",
 			mockSetup: func() {
-				mockSwiftRepo.EXPECT().GetByCountryIso2Code(ctx, "XX").Return(nil, errors.New("db error"))
+				mockSwiftRepo.EXPECT().GetByCountryIso2Code(ctx, "XX", defaultPage).Return(nil, errors.New("db error"))
 			},
 			wantCountryName: "",
 			wantSwifts:      nil,
@@ -223,7 +288,7 @@ func TestGetSwiftsDetailsByCountryIso2Code(t *testing.T) {
 			name:            "Error - unknown error",
 			countryIso2Code: "XX",
 			mockSetup: func() {
-				mockSwiftRepo.EXPECT().GetByCountryIso2Code(ctx, "XX").Return(nil, nil)
+				mockSwiftRepo.EXPECT().GetByCountryIso2Code(ctx, "XX", defaultPage).Return(nil, nil)
 				mockSwiftRepo.EXPECT().GetCountryNameByIso2Code(ctx, "XX").Return("", errors.New("db error"))
 			},
 			wantCountryName: "",
@@ -235,7 +300,7 @@ func TestGetSwiftsDetailsByCountryIso2Code(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			gotCountryName, gotSwifts, err := service.GetSwiftsDetailsByCountryIso2Code(ctx, tt.countryIso2Code, mockSwiftRepo)
+			gotCountryName, gotSwifts, gotNextCursor, err := service.GetSwiftsDetailsByCountryIso2Code(ctx, tt.countryIso2Code, tt.page, mockSwiftRepo)
 			if tt.wantErr != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tt.wantErr, err)
@@ -244,6 +309,7 @@ func TestGetSwiftsDetailsByCountryIso2Code(t *testing.T) {
 			}
 			assert.Equal(t, tt.wantCountryName, gotCountryName)
 			assert.Equal(t, tt.wantSwifts, gotSwifts)
+			assert.Equal(t, tt.wantNextCursor, gotNextCursor)
 		})
 	}
 }
@@ -257,11 +323,27 @@ func TestAddSwift(t *testing.T) {
 	mockValidator := mocks.NewMockSwiftValidator(ctrl)
 	ctx := context.Background()
 
+	runInTx := func(ctx context.Context, fn func(context.Context, repositories.SwiftRepo) error) error {
+		return fn(ctx, mockSwiftRepo)
+	}
+
+	newBranch := func() *models.Swift {
+		return &models.Swift{
+			SwiftCode:   "ABCDEFGH001",
+			BankName:    "Test Bank",
+			Address:     "456 Test St",
+			CountryIso2: "US",
+			CountryName: "United States",
+		}
+	}
+
 	tests := []struct {
-		name      string
-		swift     *models.Swift
-		mockSetup func()
-		wantErr   error
+		name               string
+		swift              *models.Swift
+		missingHeadquarter string
+		mockSetup          func()
+		wantErr            error
+		wantHeadquarter    string
 	}{
 		{
 			name: "Success - Add Swift",
@@ -276,10 +358,61 @@ func TestAddSwift(t *testing.T) {
 			mockSetup: func() {
 				mockValidator.EXPECT().Struct(gomock.Any()).Return(nil)
 				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
 				mockSwiftRepo.EXPECT().AddSwift(ctx, gomock.Any()).Return(nil)
+				mockSwiftRepo.EXPECT().LinkBranches(ctx, "ABCDEFGHXXX").Return(nil)
+				mockSwiftRepo.EXPECT().AddAudit(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, audit *models.SwiftAudit) error {
+					assert.Equal(t, models.AuditActionAdd, audit.Action)
+					assert.Equal(t, "ABCDEFGHXXX", audit.SwiftCode)
+					assert.Equal(t, models.AnonymousActor, audit.Actor)
+					assert.Nil(t, audit.Before)
+					assert.NotNil(t, audit.After)
+					return nil
+				})
 			},
 			wantErr: nil,
 		},
+		{
+			name:  "Success - Branch linked to its headquarter",
+			swift: newBranch(),
+			mockSetup: func() {
+				mockValidator.EXPECT().Struct(gomock.Any()).Return(nil)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGH001").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(&models.Swift{}, nil)
+				mockSwiftRepo.EXPECT().AddSwift(ctx, gomock.Any()).Return(nil)
+				mockSwiftRepo.EXPECT().AddAudit(ctx, gomock.Any()).Return(nil)
+			},
+			wantErr:         nil,
+			wantHeadquarter: "ABCDEFGHXXX",
+		},
+		{
+			name:  "Success - Branch without headquarter is flagged",
+			swift: newBranch(),
+			mockSetup: func() {
+				mockValidator.EXPECT().Struct(gomock.Any()).Return(nil)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGH001").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().AddSwift(ctx, gomock.Any()).Return(nil)
+				mockSwiftRepo.EXPECT().AddAudit(ctx, gomock.Any()).Return(nil)
+			},
+			wantErr:         nil,
+			wantHeadquarter: "",
+		},
+		{
+			name:               "Error - Branch without headquarter is rejected",
+			swift:              newBranch(),
+			missingHeadquarter: models.MissingHeadquarterReject,
+			mockSetup: func() {
+				mockValidator.EXPECT().Struct(gomock.Any()).Return(nil)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGH001").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().AddSwift(ctx, gomock.Any()).Times(0)
+			},
+			wantErr: customErrors.ErrHeadquarterNotFound,
+		},
 		{
 			name: "Error - Swift Code Already Exists",
 			swift: &models.Swift{
@@ -358,6 +491,7 @@ func TestAddSwift(t *testing.T) {
 			mockSetup: func() {
 				mockValidator.EXPECT().Struct(gomock.Any()).Return(nil)
 				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
 				mockSwiftRepo.EXPECT().AddSwift(ctx, gomock.Any()).Return(errors.New("db error"))
 			},
 			wantErr: errors.New("db error"),
@@ -367,12 +501,14 @@ func TestAddSwift(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
+			service.MissingHeadquarter = tt.missingHeadquarter
 			err := service.AddSwift(ctx, tt.swift, mockSwiftRepo, mockValidator)
 			if tt.wantErr != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tt.wantErr, err)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.wantHeadquarter, tt.swift.HeadquarterCode)
 			}
 		})
 	}
@@ -386,21 +522,113 @@ func TestDeleteSwift(t *testing.T) {
 	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
 	ctx := context.Background()
 
+	runInTx := func(ctx context.Context, fn func(context.Context, repositories.SwiftRepo) error) error {
+		return fn(ctx, mockSwiftRepo)
+	}
+
 	tests := []struct {
-		name      string
-		swiftCode string
-		mockSetup func()
-		wantErr   error
+		name              string
+		swiftCode         string
+		ifMatch           string
+		headquarterDelete string
+		mockSetup         func()
+		wantErr           error
 	}{
 		{
 			name:      "Success - Delete Swift",
 			swiftCode: "ABcDEFGHXXX",
 			mockSetup: func() {
 				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(&models.Swift{}, nil)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().UnlinkBranches(ctx, "ABCDEFGHXXX").Return(nil)
+				mockSwiftRepo.EXPECT().DeleteSwift(ctx, "ABCDEFGHXXX").Return(nil)
+				mockSwiftRepo.EXPECT().AddAudit(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, audit *models.SwiftAudit) error {
+					assert.Equal(t, models.AuditActionDelete, audit.Action)
+					assert.NotNil(t, audit.Before)
+					assert.Nil(t, audit.After)
+					return nil
+				})
+			},
+			wantErr: nil,
+		},
+		{
+			name:      "Success - Delete branch",
+			swiftCode: "ABCDEFGH001",
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGH001").Return(&models.Swift{}, nil)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().DeleteSwift(ctx, "ABCDEFGH001").Return(nil)
+				mockSwiftRepo.EXPECT().AddAudit(ctx, gomock.Any()).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:      "Success - If-Match matches",
+			swiftCode: "ABCDEFGH001",
+			ifMatch:   `"ABCDEFGH001-6", "ABCDEFGH001-7"`,
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGH001").Return(&models.Swift{}, nil)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().LockCountryVersion(ctx, "EF").Return(models.CountryVersion{CountryIso2: "EF", Version: 3}, nil)
+				mockSwiftRepo.EXPECT().GetSwiftVersion(ctx, "ABCDEFGH001").Return(models.SwiftVersion{SwiftCode: "ABCDEFGH001", UpdatedAt: time.Unix(0, 7)}, nil)
+				mockSwiftRepo.EXPECT().DeleteSwift(ctx, "ABCDEFGH001").Return(nil)
+				mockSwiftRepo.EXPECT().AddAudit(ctx, gomock.Any()).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:      "Error - If-Match does not match",
+			swiftCode: "ABCDEFGH001",
+			ifMatch:   `"ABCDEFGH001-6"`,
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGH001").Return(&models.Swift{}, nil)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().LockCountryVersion(ctx, "EF").Return(models.CountryVersion{CountryIso2: "EF", Version: 3}, nil)
+				mockSwiftRepo.EXPECT().GetSwiftVersion(ctx, "ABCDEFGH001").Return(models.SwiftVersion{SwiftCode: "ABCDEFGH001", UpdatedAt: time.Unix(0, 7)}, nil)
+				mockSwiftRepo.EXPECT().DeleteSwift(ctx, gomock.Any()).Times(0)
+			},
+			wantErr: customErrors.ErrPreconditionFailed,
+		},
+		{
+			name:              "Success - Cascade to branches",
+			swiftCode:         "ABCDEFGHXXX",
+			headquarterDelete: models.HeadquarterDeleteCascade,
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(&models.Swift{}, nil)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().GetBranchesBySwiftCode(ctx, "ABCDEFGHXXX").Return([]models.SwiftMini{{SwiftCode: "ABCDEFGH001"}}, nil)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGH001").Return(&models.Swift{}, nil)
+				mockSwiftRepo.EXPECT().DeleteBranches(ctx, "ABCDEFGHXXX").Return(nil)
 				mockSwiftRepo.EXPECT().DeleteSwift(ctx, "ABCDEFGHXXX").Return(nil)
+				mockSwiftRepo.EXPECT().AddAudit(ctx, gomock.Any()).Return(nil).Times(2)
 			},
 			wantErr: nil,
 		},
+		{
+			name:              "Success - Block without branches",
+			swiftCode:         "ABCDEFGHXXX",
+			headquarterDelete: models.HeadquarterDeleteBlock,
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(&models.Swift{}, nil)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().GetBranchesBySwiftCode(ctx, "ABCDEFGHXXX").Return([]models.SwiftMini{}, nil)
+				mockSwiftRepo.EXPECT().DeleteSwift(ctx, "ABCDEFGHXXX").Return(nil)
+				mockSwiftRepo.EXPECT().AddAudit(ctx, gomock.Any()).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:              "Error - Blocked by branches",
+			swiftCode:         "ABCDEFGHXXX",
+			headquarterDelete: models.HeadquarterDeleteBlock,
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(&models.Swift{}, nil)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().GetBranchesBySwiftCode(ctx, "ABCDEFGHXXX").Return([]models.SwiftMini{{SwiftCode: "ABCDEFGH001"}}, nil)
+				mockSwiftRepo.EXPECT().DeleteSwift(ctx, gomock.Any()).Times(0)
+			},
+			wantErr: customErrors.ErrHeadquarterHasBranches,
+		},
 		{
 			name:      "Error - Swift Not Found",
 			swiftCode: "INVALIDCODE",
@@ -422,6 +650,7 @@ func TestDeleteSwift(t *testing.T) {
 			swiftCode: "INVALIDCODE",
 			mockSetup: func() {
 				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "INVALIDCODE").Return(nil, nil)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
 				mockSwiftRepo.EXPECT().DeleteSwift(ctx, "INVALIDCODE").Return(errors.New("db error"))
 			},
 			wantErr: errors.New("db error"),
@@ -431,13 +660,637 @@ func TestDeleteSwift(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			err := service.DeleteSwift(ctx, tt.swiftCode, mockSwiftRepo)
+			service.HeadquarterDelete = tt.headquarterDelete
+			err := service.DeleteSwift(ctx, tt.swiftCode, tt.ifMatch, mockSwiftRepo)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateSwift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := &SwiftServiceDefault{}
+	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
+	mockValidator := mocks.NewMockSwiftValidator(ctrl)
+	ctx := context.Background()
+
+	runInTx := func(ctx context.Context, fn func(context.Context, repositories.SwiftRepo) error) error {
+		return fn(ctx, mockSwiftRepo)
+	}
+
+	tests := []struct {
+		name      string
+		swiftCode string
+		swift     *models.Swift
+		mockSetup func()
+		wantErr   error
+	}{
+		{
+			name:      "Success - Update Swift",
+			swiftCode: "abcdefghXXX",
+			swift: &models.Swift{
+				SwiftCode:     "ABCDEFGHXXX",
+				BankName:      "Test Bank",
+				Address:       "456 New St",
+				CountryIso2:   "US",
+				CountryName:   "United States",
+				IsHeadquarter: true,
+			},
+			mockSetup: func() {
+				mockValidator.EXPECT().Struct(gomock.Any()).Return(nil)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(&models.Swift{SwiftCode: "ABCDEFGHXXX", Address: "123 Test St"}, nil)
+				mockSwiftRepo.EXPECT().UpdateSwift(ctx, gomock.Any()).Return(nil)
+				mockSwiftRepo.EXPECT().AddAudit(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, audit *models.SwiftAudit) error {
+					assert.Equal(t, models.AuditActionUpdate, audit.Action)
+					assert.Equal(t, "ABCDEFGHXXX", audit.SwiftCode)
+					assert.NotNil(t, audit.Before)
+					assert.Contains(t, string(audit.After), "456 New St")
+					return nil
+				})
+			},
+			wantErr: nil,
+		},
+		{
+			name:      "Success - Swift code taken from URL",
+			swiftCode: "ABCDEFGHXXX",
+			swift: &models.Swift{
+				BankName:      "Test Bank",
+				Address:       "456 New St",
+				CountryIso2:   "US",
+				CountryName:   "United States",
+				IsHeadquarter: true,
+			},
+			mockSetup: func() {
+				mockValidator.EXPECT().Struct(gomock.Any()).Return(nil)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(&models.Swift{}, nil)
+				mockSwiftRepo.EXPECT().UpdateSwift(ctx, gomock.Any()).Return(nil)
+				mockSwiftRepo.EXPECT().AddAudit(ctx, gomock.Any()).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:      "Error - Swift code mismatch",
+			swiftCode: "ABCDEFGHXXX",
+			swift: &models.Swift{
+				SwiftCode:     "ZZZZZZZZXXX",
+				BankName:      "Test Bank",
+				Address:       "456 New St",
+				CountryIso2:   "US",
+				CountryName:   "United States",
+				IsHeadquarter: true,
+			},
+			mockSetup: func() {},
+			wantErr:   customErrors.ErrSwiftCodeMismatch,
+		},
+		{
+			name:      "Error - validation error",
+			swiftCode: "ABCDEFGHXXX",
+			swift: &models.Swift{
+				SwiftCode: "ABCDEFGHXXX",
+			},
+			mockSetup: func() {
+				mockValidator.EXPECT().Struct(gomock.Any()).Return(errors.New("validation error"))
+			},
+			wantErr: errors.New("validation error"),
+		},
+		{
+			name:      "Error - Swift Not Found",
+			swiftCode: "ABCDEFGHXXX",
+			swift: &models.Swift{
+				SwiftCode:     "ABCDEFGHXXX",
+				BankName:      "Test Bank",
+				Address:       "456 New St",
+				CountryIso2:   "US",
+				CountryName:   "United States",
+				IsHeadquarter: true,
+			},
+			mockSetup: func() {
+				mockValidator.EXPECT().Struct(gomock.Any()).Return(nil)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(nil, sql.ErrNoRows)
+			},
+			wantErr: customErrors.ErrSwiftNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := service.UpdateSwift(ctx, tt.swiftCode, tt.swift, mockSwiftRepo, mockValidator)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPatchSwift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := &SwiftServiceDefault{}
+	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
+	mockValidator := mocks.NewMockSwiftValidator(ctrl)
+	ctx := context.Background()
+
+	runInTx := func(ctx context.Context, fn func(context.Context, repositories.SwiftRepo) error) error {
+		return fn(ctx, mockSwiftRepo)
+	}
+
+	existing := func() *models.Swift {
+		return &models.Swift{
+			SwiftCode:     "ABCDEFGHXXX",
+			BankName:      "Test Bank",
+			Address:       "123 Test St",
+			TownName:      "NEW YORK",
+			CountryIso2:   "US",
+			CountryName:   "UNITED STATES",
+			IsHeadquarter: true,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		swiftCode string
+		patch     string
+		mockSetup func()
+		wantErr   error
+	}{
+		{
+			name:      "Success - Patch address and clear town",
+			swiftCode: "ABCDEFGHXXX",
+			patch:     `{"address": "456 New St", "townName": null}`,
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(existing(), nil)
+				mockValidator.EXPECT().Struct(gomock.Any()).Return(nil)
+				patched := existing()
+				patched.Address = "456 New St"
+				patched.TownName = ""
+				mockSwiftRepo.EXPECT().UpdateSwift(ctx, patched).Return(nil)
+				mockSwiftRepo.EXPECT().AddAudit(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, audit *models.SwiftAudit) error {
+					assert.Equal(t, models.AuditActionUpdate, audit.Action)
+					assert.Contains(t, string(audit.Before), "123 Test St")
+					assert.Contains(t, string(audit.After), "456 New St")
+					return nil
+				})
+			},
+			wantErr: nil,
+		},
+		{
+			name:      "Error - patch is not a JSON object",
+			swiftCode: "ABCDEFGHXXX",
+			patch:     `["address"]`,
+			mockSetup: func() {},
+			wantErr:   customErrors.ErrBadRequest,
+		},
+		{
+			name:      "Error - Swift Not Found",
+			swiftCode: "ABCDEFGHXXX",
+			patch:     `{"address": "456 New St"}`,
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(nil, sql.ErrNoRows)
+			},
+			wantErr: customErrors.ErrSwiftNotFound,
+		},
+		{
+			name:      "Error - Swift code changed",
+			swiftCode: "ABCDEFGHXXX",
+			patch:     `{"swiftCode": "ZZZZZZZZXXX"}`,
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(existing(), nil)
+			},
+			wantErr: customErrors.ErrSwiftCodeMismatch,
+		},
+		{
+			name:      "Error - validation error",
+			swiftCode: "ABCDEFGHXXX",
+			patch:     `{"bankName": null}`,
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(existing(), nil)
+				mockValidator.EXPECT().Struct(gomock.Any()).Return(errors.New("validation error"))
+			},
+			wantErr: errors.New("validation error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := service.PatchSwift(ctx, tt.swiftCode, []byte(tt.patch), mockSwiftRepo, mockValidator)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := &SwiftServiceDefault{}
+	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		search         models.SearchQuery
+		mockSetup      func()
+		wantSwifts     []models.SwiftMini
+		wantNextOffset int
+		wantErr        error
+	}{
+		{
+			name:   "Success - Last page",
+			search: models.SearchQuery{Query: " test bank ", Country: "us"},
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().Search(ctx, models.SearchQuery{
+					Query:   "test bank",
+					Country: "US",
+					Limit:   models.DefaultPageLimit + 1,
+				}).Return([]models.SwiftMini{{SwiftCode: "ABCDEFGHXXX"}}, nil)
+			},
+			wantSwifts:     []models.SwiftMini{{SwiftCode: "ABCDEFGHXXX"}},
+			wantNextOffset: 0,
+			wantErr:        nil,
+		},
+		{
+			name:   "Success - Next offset when there are more results",
+			search: models.SearchQuery{Query: "bank", Limit: 1, Offset: 2},
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().Search(ctx, models.SearchQuery{
+					Query:  "bank",
+					Limit:  2,
+					Offset: 2,
+				}).Return([]models.SwiftMini{{SwiftCode: "ABCDEFGHXXX"}, {SwiftCode: "BCDEFGHIXXX"}}, nil)
+			},
+			wantSwifts:     []models.SwiftMini{{SwiftCode: "ABCDEFGHXXX"}},
+			wantNextOffset: 3,
+			wantErr:        nil,
+		},
+		{
+			name:      "Error - Empty query",
+			search:    models.SearchQuery{Query: "   "},
+			mockSetup: func() {},
+			wantErr:   customErrors.ErrSearchQueryRequired,
+		},
+		{
+			name:   "Error - unknown error",
+			search: models.SearchQuery{Query: "bank"},
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().Search(ctx, gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			gotSwifts, gotNextOffset, err := service.Search(ctx, tt.search, mockSwiftRepo)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantSwifts, gotSwifts)
+			assert.Equal(t, tt.wantNextOffset, gotNextOffset)
+		})
+	}
+}
+
+func TestAddSwifts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := &SwiftServiceDefault{}
+	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
+	mockValidator := mocks.NewMockSwiftValidator(ctrl)
+	ctx := context.Background()
+
+	runInTx := func(ctx context.Context, fn func(context.Context, repositories.SwiftRepo) error) error {
+		return fn(ctx, mockSwiftRepo)
+	}
+
+	newSwifts := func() []models.Swift {
+		return []models.Swift{
+			{SwiftCode: "ABCDEFGHXXX", BankName: "Test Bank", Address: "123 Test St", CountryIso2: "US", CountryName: "United States", IsHeadquarter: true},
+			{SwiftCode: "abcdefgh001", BankName: "Test Bank", Address: "456 Test St", CountryIso2: "US", CountryName: "United States"},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		swifts             []models.Swift
+		atomic             bool
+		missingHeadquarter string
+		mockSetup          func()
+		wantItemErrs       []error
+		wantErr            error
+	}{
+		{
+			name:   "Success - Atomic",
+			swifts: newSwifts(),
+			atomic: true,
+			mockSetup: func() {
+				mockValidator.EXPECT().Struct(gomock.Any()).Return(nil).Times(2)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGH001").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().AddSwift(ctx, gomock.Any()).Return(nil).Times(2)
+				mockSwiftRepo.EXPECT().LinkBranches(ctx, "ABCDEFGHXXX").Return(nil)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(&models.Swift{}, nil)
+				mockSwiftRepo.EXPECT().AddAudit(ctx, gomock.Any()).Return(nil).Times(2)
+			},
+			wantItemErrs: []error{nil, nil},
+			wantErr:      nil,
+		},
+		{
+			name:   "Error - Atomic batch aborted by an existing code",
+			swifts: newSwifts(),
+			atomic: true,
+			mockSetup: func() {
+				mockValidator.EXPECT().Struct(gomock.Any()).Return(nil).Times(2)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(&models.Swift{}, nil)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGH001").Return(nil, sql.ErrNoRows)
+			},
+			wantItemErrs: []error{customErrors.ErrSwiftCodeAlreadyExists, customErrors.ErrBulkAborted},
+			wantErr:      nil,
+		},
+		{
+			name: "Error - Atomic batch with a duplicated code",
+			swifts: []models.Swift{
+				{SwiftCode: "ABCDEFGHXXX", IsHeadquarter: true},
+				{SwiftCode: "ABCDEFGHXXX", IsHeadquarter: true},
+			},
+			atomic: true,
+			mockSetup: func() {
+				mockValidator.EXPECT().Struct(gomock.Any()).Return(nil).Times(2)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(nil, sql.ErrNoRows)
+			},
+			wantItemErrs: []error{customErrors.ErrBulkAborted, customErrors.ErrSwiftCodeAlreadyExists},
+			wantErr:      nil,
+		},
+		{
+			name:   "Error - Atomic insert fails",
+			swifts: newSwifts(),
+			atomic: true,
+			mockSetup: func() {
+				mockValidator.EXPECT().Struct(gomock.Any()).Return(nil).Times(2)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, gomock.Any()).Return(nil, sql.ErrNoRows).Times(2)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().AddSwift(ctx, gomock.Any()).Return(errors.New("db error"))
+			},
+			wantItemErrs: nil,
+			wantErr:      errors.New("db error"),
+		},
+		{
+			name:   "Success - Best effort keeps valid items",
+			swifts: newSwifts(),
+			atomic: false,
+			mockSetup: func() {
+				mockValidator.EXPECT().Struct(gomock.Any()).Return(errors.New("validation error"))
+				mockValidator.EXPECT().Struct(gomock.Any()).Return(nil)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGH001").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().AddSwift(ctx, gomock.Any()).Return(nil)
+				mockSwiftRepo.EXPECT().AddAudit(ctx, gomock.Any()).Return(nil)
+			},
+			wantItemErrs: []error{errors.New("validation error"), nil},
+			wantErr:      nil,
+		},
+		{
+			name: "Error - Atomic batch with a branch of a missing headquarter",
+			swifts: []models.Swift{
+				{SwiftCode: "ABCDEFGH001", BankName: "Test Bank", Address: "456 Test St", CountryIso2: "US", CountryName: "United States"},
+				{SwiftCode: "IJKLMNOPXXX", BankName: "Other Bank", Address: "789 Test St", CountryIso2: "US", CountryName: "United States", IsHeadquarter: true},
+			},
+			atomic:             true,
+			missingHeadquarter: models.MissingHeadquarterReject,
+			mockSetup: func() {
+				mockValidator.EXPECT().Struct(gomock.Any()).Return(nil).Times(2)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGH001").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "IJKLMNOPXXX").Return(nil, sql.ErrNoRows)
+			},
+			wantItemErrs: []error{customErrors.ErrHeadquarterNotFound, customErrors.ErrBulkAborted},
+			wantErr:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			service.MissingHeadquarter = tt.missingHeadquarter
+			gotItemErrs, err := service.AddSwifts(ctx, tt.swifts, tt.atomic, mockSwiftRepo, mockValidator)
 			if tt.wantErr != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tt.wantErr, err)
 			} else {
 				assert.NoError(t, err)
 			}
+			assert.Equal(t, tt.wantItemErrs, gotItemErrs)
+		})
+	}
+}
+
+func TestExportSwifts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := &SwiftServiceDefault{}
+	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
+	ctx := context.Background()
+
+	mockSwiftRepo.EXPECT().ForEachSwift(ctx, "PL", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, fn func(*models.Swift) error) error {
+			return fn(&models.Swift{SwiftCode: "ABCDEFGHXXX"})
+		},
+	)
+
+	var exported []string
+	err := service.ExportSwifts(ctx, "pl", mockSwiftRepo, func(swift *models.Swift) error {
+		exported = append(exported, swift.SwiftCode)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ABCDEFGHXXX"}, exported)
+}
+
+func TestGetSwiftHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := &SwiftServiceDefault{}
+	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
+	ctx := context.Background()
+
+	history := []models.SwiftAudit{{ID: 1, SwiftCode: "ABCDEFGHXXX", Action: models.AuditActionAdd}}
+
+	tests := []struct {
+		name        string
+		swiftCode   string
+		mockSetup   func()
+		wantHistory []models.SwiftAudit
+		wantErr     error
+	}{
+		{
+			name:      "Success - History of a deleted code",
+			swiftCode: "abcdefghXXX",
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetAuditBySwiftCode(ctx, "ABCDEFGHXXX").Return(history, nil)
+			},
+			wantHistory: history,
+		},
+		{
+			name:      "Success - Imported code without history",
+			swiftCode: "ABCDEFGHXXX",
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetAuditBySwiftCode(ctx, "ABCDEFGHXXX").Return([]models.SwiftAudit{}, nil)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(&models.Swift{}, nil)
+			},
+			wantHistory: []models.SwiftAudit{},
+		},
+		{
+			name:      "Error - Swift Not Found",
+			swiftCode: "ABCDEFGHXXX",
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetAuditBySwiftCode(ctx, "ABCDEFGHXXX").Return([]models.SwiftAudit{}, nil)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(nil, sql.ErrNoRows)
+			},
+			wantErr: customErrors.ErrSwiftNotFound,
+		},
+		{
+			name:      "Error - unknown error",
+			swiftCode: "ABCDEFGHXXX",
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetAuditBySwiftCode(ctx, "ABCDEFGHXXX").Return(nil, errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			gotHistory, err := service.GetSwiftHistory(ctx, tt.swiftCode, mockSwiftRepo)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantHistory, gotHistory)
+		})
+	}
+}
+
+func TestRestoreSwift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := &SwiftServiceDefault{}
+	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
+	ctx := context.Background()
+
+	runInTx := func(ctx context.Context, fn func(context.Context, repositories.SwiftRepo) error) error {
+		return fn(ctx, mockSwiftRepo)
+	}
+
+	tests := []struct {
+		name               string
+		swiftCode          string
+		missingHeadquarter string
+		mockSetup          func()
+		wantErr            error
+	}{
+		{
+			name:      "Success - Restore headquarter",
+			swiftCode: "abcdefghXXX",
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().RestoreSwift(ctx, "ABCDEFGHXXX").Return(nil)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(&models.Swift{SwiftCode: "ABCDEFGHXXX"}, nil)
+				mockSwiftRepo.EXPECT().LinkBranches(ctx, "ABCDEFGHXXX").Return(nil)
+				mockSwiftRepo.EXPECT().AddAudit(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, audit *models.SwiftAudit) error {
+					assert.Equal(t, models.AuditActionRestore, audit.Action)
+					return nil
+				})
+			},
+		},
+		{
+			name:      "Success - Restore branch",
+			swiftCode: "ABCDEFGH001",
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGH001").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().RestoreSwift(ctx, "ABCDEFGH001").Return(nil)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGH001").Return(&models.Swift{SwiftCode: "ABCDEFGH001"}, nil)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(&models.Swift{}, nil)
+				mockSwiftRepo.EXPECT().UpdateSwift(ctx, &models.Swift{SwiftCode: "ABCDEFGH001", HeadquarterCode: "ABCDEFGHXXX"}).Return(nil)
+				mockSwiftRepo.EXPECT().AddAudit(ctx, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:               "Error - Branch without headquarter is rejected",
+			swiftCode:          "ABCDEFGH001",
+			missingHeadquarter: models.MissingHeadquarterReject,
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGH001").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().RestoreSwift(ctx, "ABCDEFGH001").Return(nil)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGH001").Return(&models.Swift{SwiftCode: "ABCDEFGH001"}, nil)
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(nil, sql.ErrNoRows)
+			},
+			wantErr: customErrors.ErrHeadquarterNotFound,
+		},
+		{
+			name:      "Error - Swift is not deleted",
+			swiftCode: "ABCDEFGHXXX",
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(&models.Swift{}, nil)
+			},
+			wantErr: customErrors.ErrSwiftNotDeleted,
+		},
+		{
+			name:      "Error - Swift Not Found",
+			swiftCode: "ABCDEFGHXXX",
+			mockSetup: func() {
+				mockSwiftRepo.EXPECT().GetBySwiftCode(ctx, "ABCDEFGHXXX").Return(nil, sql.ErrNoRows)
+				mockSwiftRepo.EXPECT().RunInTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				mockSwiftRepo.EXPECT().RestoreSwift(ctx, "ABCDEFGHXXX").Return(sql.ErrNoRows)
+			},
+			wantErr: customErrors.ErrSwiftNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			service.MissingHeadquarter = tt.missingHeadquarter
+			err := service.RestoreSwift(ctx, tt.swiftCode, mockSwiftRepo)
+			assert.Equal(t, tt.wantErr, err)
 		})
 	}
 }
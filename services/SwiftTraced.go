@@ -0,0 +1,158 @@
+package services
+
+import (
+	"awesomeProject/customErrors"
+	"awesomeProject/models"
+	"awesomeProject/repositories"
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+)
+
+const tracerName = "awesomeProject/services"
+
+// SwiftServiceTraced wraps every method of a SwiftService in a span named
+// after it, so repository queries nest under the service call that made them.
+type SwiftServiceTraced struct {
+	SwiftService SwiftService
+	tracer       trace.Tracer
+}
+
+func NewSwiftServiceTraced(swiftService SwiftService) *SwiftServiceTraced {
+	return &SwiftServiceTraced{
+		SwiftService: swiftService,
+		tracer:       otel.Tracer(tracerName),
+	}
+}
+
+func (s *SwiftServiceTraced) start(ctx context.Context, method string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "SwiftService."+method, trace.WithAttributes(attributes...))
+}
+
+// end records err on span. Errors the client caused, such as a missing swift
+// code, don't mark the span as failed.
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		var httpErr *customErrors.HttpError
+		if !errors.As(err, &httpErr) || httpErr.Code() >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
+
+func (s *SwiftServiceTraced) GetSwiftDetails(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) (
+	swift *models.Swift,
+	branches []models.SwiftMini,
+	parent *models.BranchParent,
+	err error,
+) {
+	ctx, span := s.start(ctx, "GetSwiftDetails", attribute.String("swift.code", swiftCode))
+	defer func() { end(span, err) }()
+	return s.SwiftService.GetSwiftDetails(ctx, swiftCode, swiftRepo)
+}
+
+func (s *SwiftServiceTraced) GetSwiftsDetailsByCountryIso2Code(ctx context.Context, countryIso2Code string, page models.PageRequest, swiftRepo repositories.SwiftRepo) (
+	countryName string,
+	swifts []models.SwiftMini,
+	nextCursor string,
+	err error,
+) {
+	ctx, span := s.start(ctx, "GetSwiftsDetailsByCountryIso2Code",
+		attribute.String("swift.country", countryIso2Code),
+		attribute.Int("page.limit", page.Limit),
+	)
+	defer func() { end(span, err) }()
+	countryName, swifts, nextCursor, err = s.SwiftService.GetSwiftsDetailsByCountryIso2Code(ctx, countryIso2Code, page, swiftRepo)
+	span.SetAttributes(attribute.Int("swift.count", len(swifts)))
+	return
+}
+
+func (s *SwiftServiceTraced) Search(ctx context.Context, search models.SearchQuery, swiftRepo repositories.SwiftRepo) (
+	swifts []models.SwiftMini,
+	nextOffset int,
+	err error,
+) {
+	ctx, span := s.start(ctx, "Search", attribute.String("swift.country", search.Country))
+	defer func() { end(span, err) }()
+	swifts, nextOffset, err = s.SwiftService.Search(ctx, search, swiftRepo)
+	span.SetAttributes(attribute.Int("swift.count", len(swifts)))
+	return
+}
+
+func (s *SwiftServiceTraced) ExportSwifts(ctx context.Context, countryIso2Code string, swiftRepo repositories.SwiftRepo, write func(*models.Swift) error) (err error) {
+	ctx, span := s.start(ctx, "ExportSwifts", attribute.String("swift.country", countryIso2Code))
+	defer func() { end(span, err) }()
+	return s.SwiftService.ExportSwifts(ctx, countryIso2Code, swiftRepo, write)
+}
+
+func (s *SwiftServiceTraced) AddSwift(ctx context.Context, swift *models.Swift, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) (err error) {
+	ctx, span := s.start(ctx, "AddSwift", attribute.String("swift.code", swift.SwiftCode))
+	defer func() { end(span, err) }()
+	return s.SwiftService.AddSwift(ctx, swift, swiftRepo, validate)
+}
+
+func (s *SwiftServiceTraced) AddSwifts(ctx context.Context, swifts []models.Swift, atomic bool, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) (
+	itemErrs []error,
+	err error,
+) {
+	ctx, span := s.start(ctx, "AddSwifts",
+		attribute.Int("swift.count", len(swifts)),
+		attribute.Bool("swift.atomic", atomic),
+	)
+	defer func() { end(span, err) }()
+	return s.SwiftService.AddSwifts(ctx, swifts, atomic, swiftRepo, validate)
+}
+
+func (s *SwiftServiceTraced) CheckSwifts(ctx context.Context, swifts []models.Swift, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) []error {
+	ctx, span := s.start(ctx, "CheckSwifts", attribute.Int("swift.count", len(swifts)))
+	defer span.End()
+	return s.SwiftService.CheckSwifts(ctx, swifts, swiftRepo, validate)
+}
+
+func (s *SwiftServiceTraced) UpdateSwift(ctx context.Context, swiftCode string, swift *models.Swift, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) (err error) {
+	ctx, span := s.start(ctx, "UpdateSwift", attribute.String("swift.code", swiftCode))
+	defer func() { end(span, err) }()
+	return s.SwiftService.UpdateSwift(ctx, swiftCode, swift, swiftRepo, validate)
+}
+
+func (s *SwiftServiceTraced) PatchSwift(ctx context.Context, swiftCode string, patch []byte, swiftRepo repositories.SwiftRepo, validate models.SwiftValidator) (err error) {
+	ctx, span := s.start(ctx, "PatchSwift", attribute.String("swift.code", swiftCode))
+	defer func() { end(span, err) }()
+	return s.SwiftService.PatchSwift(ctx, swiftCode, patch, swiftRepo, validate)
+}
+
+func (s *SwiftServiceTraced) DeleteSwift(ctx context.Context, swiftCode string, ifMatch string, swiftRepo repositories.SwiftRepo) (err error) {
+	ctx, span := s.start(ctx, "DeleteSwift", attribute.String("swift.code", swiftCode))
+	defer func() { end(span, err) }()
+	return s.SwiftService.DeleteSwift(ctx, swiftCode, ifMatch, swiftRepo)
+}
+
+func (s *SwiftServiceTraced) RestoreSwift(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) (err error) {
+	ctx, span := s.start(ctx, "RestoreSwift", attribute.String("swift.code", swiftCode))
+	defer func() { end(span, err) }()
+	return s.SwiftService.RestoreSwift(ctx, swiftCode, swiftRepo)
+}
+
+func (s *SwiftServiceTraced) GetSwiftHistory(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) (audits []models.SwiftAudit, err error) {
+	ctx, span := s.start(ctx, "GetSwiftHistory", attribute.String("swift.code", swiftCode))
+	defer func() { end(span, err) }()
+	return s.SwiftService.GetSwiftHistory(ctx, swiftCode, swiftRepo)
+}
+
+func (s *SwiftServiceTraced) GetCountryVersion(ctx context.Context, countryIso2Code string, swiftRepo repositories.SwiftRepo) (version models.CountryVersion, err error) {
+	ctx, span := s.start(ctx, "GetCountryVersion", attribute.String("swift.country", countryIso2Code))
+	defer func() { end(span, err) }()
+	return s.SwiftService.GetCountryVersion(ctx, countryIso2Code, swiftRepo)
+}
+
+func (s *SwiftServiceTraced) GetSwiftVersion(ctx context.Context, swiftCode string, swiftRepo repositories.SwiftRepo) (version models.SwiftVersion, err error) {
+	ctx, span := s.start(ctx, "GetSwiftVersion", attribute.String("swift.code", swiftCode))
+	defer func() { end(span, err) }()
+	return s.SwiftService.GetSwiftVersion(ctx, swiftCode, swiftRepo)
+}
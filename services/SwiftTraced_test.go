@@ -0,0 +1,114 @@
+package services
+
+import (
+	"awesomeProject/customErrors"
+	"awesomeProject/mocks"
+	"awesomeProject/models"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestSwiftServiceTraced(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSwiftService := mocks.NewMockSwiftService(ctrl)
+	mockSwiftRepo := mocks.NewMockSwiftRepo(ctrl)
+	dbErr := errors.New("connection refused")
+
+	tests := []struct {
+		name               string
+		call               func(ctx context.Context, service *SwiftServiceTraced) error
+		expectedName       string
+		expectedAttributes []attribute.KeyValue
+		expectedStatus     codes.Code
+		expectedEvents     int
+	}{
+		{
+			name: "Success",
+			call: func(ctx context.Context, service *SwiftServiceTraced) error {
+				mockSwiftService.EXPECT().GetSwiftDetails(gomock.Any(), "ABCDPLPWXXX", mockSwiftRepo).Return(&models.Swift{SwiftCode: "ABCDPLPWXXX"}, nil, nil, nil)
+				_, _, _, err := service.GetSwiftDetails(ctx, "ABCDPLPWXXX", mockSwiftRepo)
+				return err
+			},
+			expectedName:       "SwiftService.GetSwiftDetails",
+			expectedAttributes: []attribute.KeyValue{attribute.String("swift.code", "ABCDPLPWXXX")},
+			expectedStatus:     codes.Unset,
+		},
+		{
+			name: "Result attributes",
+			call: func(ctx context.Context, service *SwiftServiceTraced) error {
+				page := models.PageRequest{Limit: 10}
+				mockSwiftService.EXPECT().GetSwiftsDetailsByCountryIso2Code(gomock.Any(), "PL", page, mockSwiftRepo).Return("POLAND", []models.SwiftMini{{SwiftCode: "ABCDPLPWXXX"}, {SwiftCode: "ABCDPLPW001"}}, "", nil)
+				_, _, _, err := service.GetSwiftsDetailsByCountryIso2Code(ctx, "PL", page, mockSwiftRepo)
+				return err
+			},
+			expectedName: "SwiftService.GetSwiftsDetailsByCountryIso2Code",
+			expectedAttributes: []attribute.KeyValue{
+				attribute.String("swift.country", "PL"),
+				attribute.Int("page.limit", 10),
+				attribute.Int("swift.count", 2),
+			},
+			expectedStatus: codes.Unset,
+		},
+		{
+			name: "Client error",
+			call: func(ctx context.Context, service *SwiftServiceTraced) error {
+				mockSwiftService.EXPECT().DeleteSwift(gomock.Any(), "ABCDPLPWXXX", "", mockSwiftRepo).Return(customErrors.ErrSwiftNotFound)
+				return service.DeleteSwift(ctx, "ABCDPLPWXXX", "", mockSwiftRepo)
+			},
+			expectedName:       "SwiftService.DeleteSwift",
+			expectedAttributes: []attribute.KeyValue{attribute.String("swift.code", "ABCDPLPWXXX")},
+			expectedStatus:     codes.Unset,
+			expectedEvents:     1,
+		},
+		{
+			name: "Server error",
+			call: func(ctx context.Context, service *SwiftServiceTraced) error {
+				mockSwiftService.EXPECT().Search(gomock.Any(), models.SearchQuery{Country: "PL"}, mockSwiftRepo).Return(nil, 0, dbErr)
+				_, _, err := service.Search(ctx, models.SearchQuery{Country: "PL"}, mockSwiftRepo)
+				return err
+			},
+			expectedName: "SwiftService.Search",
+			expectedAttributes: []attribute.KeyValue{
+				attribute.String("swift.country", "PL"),
+				attribute.Int("swift.count", 0),
+			},
+			expectedStatus: codes.Error,
+			expectedEvents: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			service := &SwiftServiceTraced{
+				SwiftService: mockSwiftService,
+				tracer:       sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracerName),
+			}
+
+			ctx, parent := service.tracer.Start(context.Background(), "parent")
+			err := tt.call(ctx, service)
+			parent.End()
+
+			spans := recorder.Ended()
+			assert.Len(t, spans, 2)
+			span := spans[0]
+			assert.Equal(t, tt.expectedName, span.Name())
+			assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+			assert.Equal(t, tt.expectedAttributes, span.Attributes())
+			assert.Equal(t, tt.expectedStatus, span.Status().Code)
+			assert.Len(t, span.Events(), tt.expectedEvents)
+			if tt.expectedStatus == codes.Error {
+				assert.Equal(t, err.Error(), span.Status().Description)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package tests
 import (
 	"awesomeProject/configs"
 	"awesomeProject/controllers"
+	"awesomeProject/customErrors"
 	"awesomeProject/dbs"
 	"awesomeProject/dbs/migrations"
 	"awesomeProject/internal/dbimporter/utils"
@@ -14,30 +15,51 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/go-playground/validator/v10"
 	"github.com/joho/godotenv"
 	"github.com/stretchr/testify/assert"
 	"github.com/uptrace/bun"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
-func setupTestEnvironment(t *testing.T) (*bun.DB, *controllers.Controller) {
+func setupTestEnvironment(t *testing.T) (*controllers.Controller, func()) {
 	err := godotenv.Load("../.env")
-
-	config := configs.GetConfig()
 	if err != nil {
-		fmt.Printf("Env file error: %v", err)
+		fmt.Printf("Env file error: %v, falling back to the in-memory repository\n", err)
+		t.Setenv("SWIFT_REPOSITORY", configs.RepositoryMemory)
+		t.Setenv("AUTH_ENABLED", "false")
 	} else {
-		config.DBConfig.Host = "localhost"
+		t.Setenv("DB_HOST", "localhost")
+	}
+
+	config, err := configs.Load("")
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	validate := models.NewSwiftValidator()
+
+	swiftService := services.SwiftServiceDefault{}
+
+	swiftController := controllers.Controller{
+		SwiftService: &swiftService,
+		Validate:     validate,
+	}
+
+	if config.Repository == configs.RepositoryMemory {
+		swiftController.SwiftRepo = repositories.NewSwiftRepoMemory()
+		return &swiftController, func() {}
 	}
 
 	db := dbs.Connect(&config.DBConfig)
 
-	_, err = db.NewDropTable().IfExists().Model((*models.Swift)(nil)).Exec(context.Background())
+	err = resetDatabase(db)
 	if err != nil {
-		t.Fatalf("Failed to drop table: %v", err)
+		t.Fatalf("Failed to reset database: %v", err)
 	}
 
 	err = migrations.Migrate(db)
@@ -45,28 +67,29 @@ func setupTestEnvironment(t *testing.T) (*bun.DB, *controllers.Controller) {
 		t.Fatalf("Failed to migrate database: %v", err)
 	}
 
-	swiftRepo := &repositories.SwiftRepoPostgres{
+	swiftController.SwiftRepo = &repositories.SwiftRepoPostgres{
 		Db: &dbs.BunDBWrapper{DB: db},
 	}
 
-	validate := validator.New()
-	validate.RegisterStructValidation(models.SwiftStructLevelValidation, models.Swift{})
-
-	swiftService := services.SwiftServiceDefault{}
+	return &swiftController, func() { afterTest(db) }
+}
 
-	swiftController := controllers.Controller{
-		SwiftService: &swiftService,
-		SwiftRepo:    swiftRepo,
-		Validate:     validate,
+// resetDatabase rolls back every migration and drops the table in case it was created
+// before migrations were tracked.
+func resetDatabase(db *bun.DB) error {
+	err := migrations.Reset(db)
+	if err != nil {
+		return err
 	}
 
-	return db, &swiftController
+	_, err = db.NewDropTable().IfExists().Model((*models.Swift)(nil)).Exec(context.Background())
+	return err
 }
 
 func afterTest(db *bun.DB) {
-	_, err := db.NewDropTable().IfExists().Model((*models.Swift)(nil)).Exec(context.Background())
+	err := resetDatabase(db)
 	if err != nil {
-		fmt.Printf("Failed to drop table: %v", err)
+		fmt.Printf("Failed to reset database: %v", err)
 	}
 
 	err = migrations.Migrate(db)
@@ -74,7 +97,7 @@ func afterTest(db *bun.DB) {
 		fmt.Printf("Failed to migrate database: %v", err)
 	}
 
-	err = utils.ImportData("../data.csv", db)
+	_, err = utils.ImportData(context.Background(), "../data.csv", db, utils.ImportOptions{})
 	if err != nil {
 		fmt.Println(err)
 	}
@@ -83,8 +106,8 @@ func afterTest(db *bun.DB) {
 }
 
 func TestAddSwift(t *testing.T) {
-	db, swiftController := setupTestEnvironment(t)
-	defer afterTest(db)
+	swiftController, teardown := setupTestEnvironment(t)
+	defer teardown()
 
 	router := routes.SetupRouter(swiftController)
 
@@ -117,8 +140,8 @@ func TestAddSwift(t *testing.T) {
 }
 
 func TestGetSwiftDetails(t *testing.T) {
-	db, swiftController := setupTestEnvironment(t)
-	defer afterTest(db)
+	swiftController, teardown := setupTestEnvironment(t)
+	defer teardown()
 
 	router := routes.SetupRouter(swiftController)
 
@@ -127,10 +150,13 @@ func TestGetSwiftDetails(t *testing.T) {
 
 	swift := models.Swift{
 		SwiftCode:     "XXXXXXXXXXX",
+		CodeType:      "BIC11",
 		BankName:      "Test Bank",
 		Address:       "123 Test Street",
+		TownName:      "NEW YORK",
 		CountryIso2:   "US",
 		CountryName:   "UNITED STATES",
+		TimeZone:      "America/New_York",
 		IsHeadquarter: true,
 	}
 
@@ -159,11 +185,14 @@ func TestGetSwiftDetails(t *testing.T) {
 	assert.Equal(t, swift.CountryIso2, response["countryISO2"])
 	assert.Equal(t, swift.CountryName, response["countryName"])
 	assert.Equal(t, swift.IsHeadquarter, response["isHeadquarter"])
+	assert.Equal(t, swift.CodeType, response["codeType"])
+	assert.Equal(t, swift.TownName, response["townName"])
+	assert.Equal(t, swift.TimeZone, response["timeZone"])
 }
 
 func TestDeleteSwift(t *testing.T) {
-	db, swiftController := setupTestEnvironment(t)
-	defer afterTest(db)
+	swiftController, teardown := setupTestEnvironment(t)
+	defer teardown()
 
 	router := routes.SetupRouter(swiftController)
 
@@ -206,8 +235,8 @@ func TestDeleteSwift(t *testing.T) {
 }
 
 func TestGetSwiftsDetailsByCountryIso2Code(t *testing.T) {
-	db, swiftController := setupTestEnvironment(t)
-	defer afterTest(db)
+	swiftController, teardown := setupTestEnvironment(t)
+	defer teardown()
 
 	router := routes.SetupRouter(swiftController)
 
@@ -246,3 +275,752 @@ func TestGetSwiftsDetailsByCountryIso2Code(t *testing.T) {
 	assert.Equal(t, "UNITED STATES", response["countryName"])
 	assert.NotEmpty(t, response["swiftCodes"])
 }
+
+func TestUpdateSwift(t *testing.T) {
+	swiftController, teardown := setupTestEnvironment(t)
+	defer teardown()
+
+	router := routes.SetupRouter(swiftController)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	swift := models.Swift{
+		SwiftCode:     "XXXXXXXXXXX",
+		BankName:      "Test Bank",
+		Address:       "123 Test Street",
+		CountryIso2:   "US",
+		CountryName:   "UNITED STATES",
+		IsHeadquarter: true,
+	}
+
+	jsonData, err := json.Marshal(swift)
+	assert.NoError(t, err)
+
+	resp, err := http.Post(server.URL+"/v1/swift-codes/", "application/json", bytes.NewBuffer(jsonData))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	swift.Address = "456 Replaced Street"
+	jsonData, err = json.Marshal(swift)
+	assert.NoError(t, err)
+
+	client := &http.Client{}
+	req, err := http.NewRequest(http.MethodPut, server.URL+"/v1/swift-codes/"+swift.SwiftCode, bytes.NewBuffer(jsonData))
+	assert.NoError(t, err)
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	req, err = http.NewRequest(http.MethodPatch, server.URL+"/v1/swift-codes/"+swift.SwiftCode, bytes.NewBufferString(`{"bankName": "Patched Bank"}`))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/v1/swift-codes/" + swift.SwiftCode)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	var response map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "456 Replaced Street", response["address"])
+	assert.Equal(t, "Patched Bank", response["bankName"])
+
+	resp, err = http.Get(server.URL + "/v1/swift-codes/" + swift.SwiftCode + "/history")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	var history struct {
+		History []models.SwiftAudit `json:"history"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&history))
+	if assert.Len(t, history.History, 3) {
+		assert.Equal(t, models.AuditActionUpdate, history.History[1].Action)
+		assert.Contains(t, string(history.History[1].After), "456 Replaced Street")
+		assert.Equal(t, models.AuditActionUpdate, history.History[2].Action)
+		assert.Contains(t, string(history.History[2].After), "Patched Bank")
+	}
+
+	req, err = http.NewRequest(http.MethodPatch, server.URL+"/v1/swift-codes/"+swift.SwiftCode, bytes.NewBufferString(`{"bankName": "Patched Bank"}`))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+
+	req, err = http.NewRequest(http.MethodPut, server.URL+"/v1/swift-codes/YYYYYYYYXXX", bytes.NewBuffer(jsonData))
+	assert.NoError(t, err)
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestGetSwiftsDetailsByCountryIso2CodePagination(t *testing.T) {
+	swiftController, teardown := setupTestEnvironment(t)
+	defer teardown()
+
+	router := routes.SetupRouter(swiftController)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	for _, swiftCode := range []string{"XXXXXXXXXXX", "XXXXXXXXAAA", "XXXXXXXXBBB"} {
+		swift := models.Swift{
+			SwiftCode:     swiftCode,
+			BankName:      "Test Bank",
+			Address:       "123 Test Street",
+			CountryIso2:   "US",
+			CountryName:   "UNITED STATES",
+			IsHeadquarter: models.IsSwiftCodeOfHeadquarter(swiftCode),
+		}
+
+		jsonData, err := json.Marshal(swift)
+		assert.NoError(t, err)
+
+		resp, err := http.Post(server.URL+"/v1/swift-codes/", "application/json", bytes.NewBuffer(jsonData))
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	var seen []string
+	url := server.URL + "/v1/swift-codes/country/US?limit=2"
+	for url != "" {
+		resp, err := http.Get(url)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response struct {
+			SwiftCodes []models.SwiftMini `json:"swiftCodes"`
+			NextCursor *string            `json:"nextCursor"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		assert.NoError(t, err)
+
+		for _, swift := range response.SwiftCodes {
+			seen = append(seen, swift.SwiftCode)
+		}
+
+		url = ""
+		if response.NextCursor != nil {
+			url = server.URL + "/v1/swift-codes/country/US?limit=2&after=" + *response.NextCursor
+		}
+	}
+
+	assert.Equal(t, []string{"XXXXXXXXAAA", "XXXXXXXXBBB", "XXXXXXXXXXX"}, seen)
+
+	// A deleted cursor still marks its place; an unknown one is rejected.
+	req, err := http.NewRequest(http.MethodDelete, server.URL+"/v1/swift-codes/XXXXXXXXAAA", nil)
+	assert.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	for _, sort := range []string{models.SortBySwiftCode, models.SortByBankName} {
+		resp, err = http.Get(server.URL + "/v1/swift-codes/country/US?sort=" + sort + "&after=XXXXXXXXAAA")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response struct {
+			SwiftCodes []models.SwiftMini `json:"swiftCodes"`
+		}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		resp.Body.Close()
+		assert.Len(t, response.SwiftCodes, 2)
+
+		resp, err = http.Get(server.URL + "/v1/swift-codes/country/US?sort=" + sort + "&after=XXXXXXXXCCC")
+		assert.NoError(t, err)
+
+		var problem customErrors.Problem
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, "INVALID_CURSOR", problem.Code)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	swiftController, teardown := setupTestEnvironment(t)
+	defer teardown()
+
+	router := routes.SetupRouter(swiftController)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	swift := models.Swift{
+		SwiftCode:     "XXXXXXXXXXX",
+		BankName:      "Searchable Test Bank",
+		Address:       "123 Test Street",
+		TownName:      "SPRINGFIELD",
+		CountryIso2:   "US",
+		CountryName:   "UNITED STATES",
+		IsHeadquarter: true,
+	}
+
+	jsonData, err := json.Marshal(swift)
+	assert.NoError(t, err)
+
+	resp, err := http.Post(server.URL+"/v1/swift-codes/", "application/json", bytes.NewBuffer(jsonData))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/v1/swift-codes/search?q=searchable&country=US")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var response struct {
+		SwiftCodes []models.SwiftMini `json:"swiftCodes"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.NotEmpty(t, response.SwiftCodes)
+	assert.Equal(t, swift.SwiftCode, response.SwiftCodes[0].SwiftCode)
+
+	resp, err = http.Get(server.URL + "/v1/swift-codes/search")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAddSwifts(t *testing.T) {
+	swiftController, teardown := setupTestEnvironment(t)
+	defer teardown()
+
+	router := routes.SetupRouter(swiftController)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	swifts := []models.Swift{
+		{
+			SwiftCode:     "XXXXXXXXXXX",
+			BankName:      "Test Bank",
+			Address:       "123 Test Street",
+			CountryIso2:   "US",
+			CountryName:   "UNITED STATES",
+			IsHeadquarter: true,
+		},
+		{
+			SwiftCode:     "XXXXXXXXAAA",
+			BankName:      "Test Bank",
+			Address:       "456 Test Street",
+			CountryIso2:   "US",
+			CountryName:   "UNITED STATES",
+			IsHeadquarter: true,
+		},
+	}
+
+	jsonData, err := json.Marshal(swifts)
+	assert.NoError(t, err)
+
+	resp, err := http.Post(server.URL+"/v1/swift-codes/bulk", "application/json", bytes.NewBuffer(jsonData))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/v1/swift-codes/" + swifts[0].SwiftCode)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Every item is checked even when one of them can't be decoded.
+	body := `[{"swiftCode": "XXXXXXXXXXX", "bankName": "Test Bank", "address": "123 Test Street", "countryISO2": "US", "countryName": "UNITED STATES", "isHeadquarter": true},` +
+		`{"isHeadquarter": "no"},` +
+		`{"swiftCode": "XXXXXXXXBBB", "address": "789 Test Street", "countryISO2": "US", "countryName": "UNITED STATES"}]`
+	resp, err = http.Post(server.URL+"/v1/swift-codes/bulk", "application/json", bytes.NewBufferString(body))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+
+	var aborted struct {
+		Results []struct {
+			Code string `json:"code"`
+		} `json:"results"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&aborted))
+	if assert.Len(t, aborted.Results, 3) {
+		assert.Equal(t, "BULK_ABORTED", aborted.Results[0].Code)
+		assert.Equal(t, "INVALID_FIELD_TYPE", aborted.Results[1].Code)
+		assert.Equal(t, "VALIDATION_FAILED", aborted.Results[2].Code)
+	}
+
+	resp, err = http.Post(server.URL+"/v1/swift-codes/bulk?mode=best-effort", "application/json", bytes.NewBuffer(jsonData))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+
+	var response struct {
+		Created int `json:"created"`
+		Failed  int `json:"failed"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, response.Created)
+	assert.Equal(t, 1, response.Failed)
+
+	resp, err = http.Get(server.URL + "/v1/swift-codes/" + swifts[0].SwiftCode)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestExport(t *testing.T) {
+	swiftController, teardown := setupTestEnvironment(t)
+	defer teardown()
+
+	router := routes.SetupRouter(swiftController)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	swift := models.Swift{
+		SwiftCode:     "XXXXXXXXXXX",
+		CodeType:      "BIC11",
+		BankName:      "Test Bank",
+		Address:       "123 Test Street, Springfield",
+		TownName:      "SPRINGFIELD",
+		CountryIso2:   "US",
+		CountryName:   "UNITED STATES",
+		TimeZone:      "America/New_York",
+		IsHeadquarter: true,
+	}
+
+	jsonData, err := json.Marshal(swift)
+	assert.NoError(t, err)
+
+	resp, err := http.Post(server.URL+"/v1/swift-codes/", "application/json", bytes.NewBuffer(jsonData))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/v1/swift-codes/export?format=csv&country=US")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	exported, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+
+	csvFilePath := filepath.Join(t.TempDir(), "export.csv")
+	err = os.WriteFile(csvFilePath, exported, 0o644)
+	assert.NoError(t, err)
+
+	reimported := repositories.NewSwiftRepoMemory()
+	result, err := utils.ImportDataToRepo(context.Background(), csvFilePath, reimported, utils.ImportOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Inserted)
+
+	got, err := reimported.GetBySwiftCode(context.Background(), swift.SwiftCode)
+	assert.NoError(t, err)
+	assert.False(t, got.UpdatedAt.IsZero())
+	swift.UpdatedAt = got.UpdatedAt
+	assert.Equal(t, swift, *got)
+}
+
+func TestHeadquarterBranches(t *testing.T) {
+	swiftController, teardown := setupTestEnvironment(t)
+	defer teardown()
+
+	swiftController.SwiftService = &services.SwiftServiceDefault{
+		HeadquarterDelete: models.HeadquarterDeleteBlock,
+	}
+	router := routes.SetupRouter(swiftController)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	headquarterMissing := func() []string {
+		resp, err := http.Get(server.URL + "/v1/swift-codes/country/PL?headquarterMissing=true&limit=1000")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var page struct {
+			SwiftCodes []models.SwiftMini `json:"swiftCodes"`
+		}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+		var swiftCodes []string
+		for _, swift := range page.SwiftCodes {
+			assert.True(t, swift.HeadquarterMissing)
+			swiftCodes = append(swiftCodes, swift.SwiftCode)
+		}
+		return swiftCodes
+	}
+
+	for _, swift := range []models.Swift{
+		{SwiftCode: "HQBRPLPWAAA", BankName: "Test Bank", Address: "1 Branch Street", CountryIso2: "PL", CountryName: "Poland"},
+		{SwiftCode: "HQBRPLPWXXX", BankName: "Test Bank", Address: "1 Main Street", CountryIso2: "PL", CountryName: "Poland", IsHeadquarter: true},
+	} {
+		jsonData, err := json.Marshal(swift)
+		assert.NoError(t, err)
+
+		resp, err := http.Post(server.URL+"/v1/swift-codes/", "application/json", bytes.NewBuffer(jsonData))
+		assert.NoError(t, err)
+		var created map[string]interface{}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+		resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		if swift.IsHeadquarter {
+			assert.NotContains(t, created, "headquarterMissing")
+			assert.NotContains(t, headquarterMissing(), "HQBRPLPWAAA")
+		} else {
+			assert.Equal(t, true, created["headquarterMissing"])
+			assert.Contains(t, headquarterMissing(), "HQBRPLPWAAA")
+		}
+	}
+
+	resp, err := http.Get(server.URL + "/v1/swift-codes/HQBRPLPWXXX")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	var details struct {
+		Branches []models.SwiftMini `json:"branches"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&details))
+	assert.Len(t, details.Branches, 1)
+	assert.Equal(t, "HQBRPLPWAAA", details.Branches[0].SwiftCode)
+
+	resp, err = http.Get(server.URL + "/v1/swift-codes/HQBRPLPWAAA")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	var branch struct {
+		Headquarter        *models.SwiftMini `json:"headquarter"`
+		SiblingBranchCount int               `json:"siblingBranchCount"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&branch))
+	if assert.NotNil(t, branch.Headquarter) {
+		assert.Equal(t, "HQBRPLPWXXX", branch.Headquarter.SwiftCode)
+	}
+	assert.Equal(t, 0, branch.SiblingBranchCount)
+
+	req, err := http.NewRequest("DELETE", server.URL+"/v1/swift-codes/HQBRPLPWXXX", nil)
+	assert.NoError(t, err)
+
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestSwiftHistory(t *testing.T) {
+	swiftController, teardown := setupTestEnvironment(t)
+	defer teardown()
+
+	router := routes.SetupRouter(swiftController)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	jsonData, err := json.Marshal(models.Swift{
+		SwiftCode: "AUDTPLPWXXX", BankName: "Audit Bank", Address: "1 Main Street", CountryIso2: "PL", CountryName: "Poland", IsHeadquarter: true,
+	})
+	assert.NoError(t, err)
+
+	req, err := http.NewRequest("POST", server.URL+"/v1/swift-codes/", bytes.NewBuffer(jsonData))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", "add-request")
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "add-request", resp.Header.Get("X-Request-ID"))
+
+	req, err = http.NewRequest("DELETE", server.URL+"/v1/swift-codes/AUDTPLPWXXX", nil)
+	assert.NoError(t, err)
+
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	deleteRequestID := resp.Header.Get("X-Request-ID")
+	assert.NotEmpty(t, deleteRequestID)
+
+	resp, err = http.Get(server.URL + "/v1/swift-codes/AUDTPLPWXXX/history")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var history struct {
+		History []models.SwiftAudit `json:"history"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&history))
+	if assert.Len(t, history.History, 2) {
+		assert.Equal(t, models.AuditActionAdd, history.History[0].Action)
+		assert.Equal(t, "add-request", history.History[0].RequestID)
+		assert.Equal(t, models.AnonymousActor, history.History[0].Actor)
+		assert.Equal(t, models.AuditActionDelete, history.History[1].Action)
+		assert.Equal(t, deleteRequestID, history.History[1].RequestID)
+		assert.JSONEq(t, string(history.History[0].After), string(history.History[1].Before))
+	}
+
+	resp, err = http.Get(server.URL + "/v1/swift-codes/NONEPLPWXXX/history")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestRestoreSwift(t *testing.T) {
+	swiftController, teardown := setupTestEnvironment(t)
+	defer teardown()
+
+	router := routes.SetupRouter(swiftController)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	jsonData, err := json.Marshal(models.Swift{
+		SwiftCode: "RSTRPLPWXXX", BankName: "Restored Bank", Address: "1 Main Street", CountryIso2: "PL", CountryName: "Poland", IsHeadquarter: true,
+	})
+	assert.NoError(t, err)
+
+	resp, err := http.Post(server.URL+"/v1/swift-codes/", "application/json", bytes.NewBuffer(jsonData))
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, err = http.Post(server.URL+"/v1/swift-codes/RSTRPLPWXXX/restore", "application/json", nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	req, err := http.NewRequest("DELETE", server.URL+"/v1/swift-codes/RSTRPLPWXXX", nil)
+	assert.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/v1/swift-codes/RSTRPLPWXXX")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, err = http.Post(server.URL+"/v1/swift-codes/RSTRPLPWXXX/restore", "application/json", nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/v1/swift-codes/RSTRPLPWXXX")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var response map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(t, "Restored Bank", response["bankName"])
+
+	resp, err = http.Post(server.URL+"/v1/swift-codes/NONEPLPWXXX/restore", "application/json", nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestConditionalRequests(t *testing.T) {
+	swiftController, teardown := setupTestEnvironment(t)
+	defer teardown()
+
+	router := routes.SetupRouter(swiftController)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	addSwift := func(swift models.Swift) {
+		jsonData, err := json.Marshal(swift)
+		assert.NoError(t, err)
+		resp, err := http.Post(server.URL+"/v1/swift-codes/", "application/json", bytes.NewBuffer(jsonData))
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+	get := func(path string, header string, value string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		assert.NoError(t, err)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	addSwift(models.Swift{
+		SwiftCode: "CONDPLPWXXX", BankName: "Conditional Bank", Address: "1 Main Street", CountryIso2: "PL", CountryName: "Poland", IsHeadquarter: true,
+	})
+
+	resp := get("/v1/swift-codes/CONDPLPWXXX", "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	assert.NotEmpty(t, etag)
+	assert.NotEmpty(t, lastModified)
+
+	resp = get("/v1/swift-codes/CONDPLPWXXX", "If-None-Match", etag)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Equal(t, etag, resp.Header.Get("ETag"))
+
+	resp = get("/v1/swift-codes/CONDPLPWXXX", "If-Modified-Since", lastModified)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+	resp = get("/v1/swift-codes/country/PL", "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	countryETag := resp.Header.Get("ETag")
+	assert.NotEmpty(t, countryETag)
+
+	// Another bank of the same country changes the country, not the code.
+	addSwift(models.Swift{
+		SwiftCode: "OTHRPLPWXXX", BankName: "Other Bank", Address: "3 Main Street", CountryIso2: "PL", CountryName: "Poland", IsHeadquarter: true,
+	})
+
+	resp = get("/v1/swift-codes/CONDPLPWXXX", "If-None-Match", etag)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+	resp = get("/v1/swift-codes/country/PL", "If-None-Match", countryETag)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEqual(t, countryETag, resp.Header.Get("ETag"))
+
+	addSwift(models.Swift{
+		SwiftCode: "CONDPLPWABC", BankName: "Conditional Bank", Address: "2 Main Street", CountryIso2: "PL", CountryName: "Poland",
+	})
+
+	resp = get("/v1/swift-codes/CONDPLPWXXX", "If-None-Match", etag)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEqual(t, etag, resp.Header.Get("ETag"))
+
+	req, err := http.NewRequest(http.MethodDelete, server.URL+"/v1/swift-codes/CONDPLPWABC", nil)
+	assert.NoError(t, err)
+	req.Header.Set("If-Match", etag)
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+	req, err = http.NewRequest(http.MethodDelete, server.URL+"/v1/swift-codes/CONDPLPWABC", nil)
+	assert.NoError(t, err)
+	req.Header.Set("If-Match", get("/v1/swift-codes/CONDPLPWABC", "", "").Header.Get("ETag"))
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestProblemResponses(t *testing.T) {
+	swiftController, teardown := setupTestEnvironment(t)
+	defer teardown()
+
+	router := routes.SetupRouter(swiftController)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	swift := models.Swift{
+		SwiftCode:     "probplpwxxx",
+		Address:       "123 Test Street",
+		CountryIso2:   "PL",
+		CountryName:   "POLAND",
+		IsHeadquarter: true,
+	}
+	jsonData, err := json.Marshal(swift)
+	assert.NoError(t, err)
+
+	resp, err := http.Post(server.URL+"/v1/swift-codes/", "application/json", bytes.NewBuffer(jsonData))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, customErrors.ProblemContentType, resp.Header.Get("Content-Type"))
+
+	var problem customErrors.Problem
+	err = json.NewDecoder(resp.Body).Decode(&problem)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "VALIDATION_FAILED", problem.Code)
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.ElementsMatch(t, []customErrors.FieldError{
+		{Field: "swiftCode", Rule: "uppercase", Message: "swiftCode must be uppercase"},
+		{Field: "bankName", Rule: "required", Message: "bankName is required"},
+	}, problem.Errors)
+
+	resp, err = http.Get(server.URL + "/v1/swift-codes/PROBPLPWXXX")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	problem = customErrors.Problem{}
+	err = json.NewDecoder(resp.Body).Decode(&problem)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "SWIFT_NOT_FOUND", problem.Code)
+	assert.Equal(t, "urn:swift-codes:problem:swift-not-found", problem.Type)
+	assert.NotEmpty(t, problem.RequestID)
+
+	resp, err = http.Get(server.URL + "/v2/swift-codes/PROBPLPWXXX")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, customErrors.ProblemContentType, resp.Header.Get("Content-Type"))
+
+	problem = customErrors.Problem{}
+	err = json.NewDecoder(resp.Body).Decode(&problem)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "NOT_FOUND", problem.Code)
+	assert.NotEmpty(t, problem.RequestID)
+
+	req, err := http.NewRequest(http.MethodDelete, server.URL+"/v1/swift-codes/country/PL", nil)
+	assert.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	assert.Equal(t, customErrors.ProblemContentType, resp.Header.Get("Content-Type"))
+	assert.Equal(t, http.MethodGet, resp.Header.Get("Allow"))
+
+	problem = customErrors.Problem{}
+	err = json.NewDecoder(resp.Body).Decode(&problem)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "METHOD_NOT_ALLOWED", problem.Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, problem.Status)
+}